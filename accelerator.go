@@ -0,0 +1,153 @@
+package ui
+
+import "fmt"
+import "strings"
+
+// Accelerator is a keyboard shortcut, such as Ctrl+S, Ctrl+Shift+Z or F5.
+// The modifiers must match exactly.
+type Accelerator struct {
+	Key Key
+	EventModifiers
+}
+
+// ParseAccelerator parses an accelerator such as "Ctrl+S", "Ctrl+Shift+Z"
+// or "F5". The modifiers are Ctrl or Control, Shift, Alt and Meta or Cmd,
+// separated from each other and from the key by a +. The key names are the
+// ebiten key names, and are case insensitive.
+func ParseAccelerator(str string) (Accelerator, error) {
+	acc := Accelerator{}
+	parts := strings.Split(str, "+")
+	for _, part := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "ctrl", "control":
+			acc.Control = true
+		case "shift":
+			acc.Shift = true
+		case "alt":
+			acc.Alt = true
+		case "meta", "cmd":
+			acc.Meta = true
+		default:
+			return acc, fmt.Errorf("accelerator %q: unknown modifier %q", str, part)
+		}
+	}
+	name := strings.TrimSpace(parts[len(parts)-1])
+	if err := acc.Key.UnmarshalText([]byte(name)); err != nil {
+		return acc, fmt.Errorf("accelerator %q: unknown key %q", str, name)
+	}
+	return acc, nil
+}
+
+// MustParseAccelerator is like ParseAccelerator but panics on error.
+func MustParseAccelerator(str string) Accelerator {
+	acc, err := ParseAccelerator(str)
+	if err != nil {
+		panic(err)
+	}
+	return acc
+}
+
+// String returns the accelerator as it is shown in a menu, such as
+// "Ctrl+Shift+Z".
+func (a Accelerator) String() string {
+	var sb strings.Builder
+	if a.Control {
+		sb.WriteString("Ctrl+")
+	}
+	if a.Alt {
+		sb.WriteString("Alt+")
+	}
+	if a.Shift {
+		sb.WriteString("Shift+")
+	}
+	if a.Meta {
+		sb.WriteString("Meta+")
+	}
+	sb.WriteString(a.Key.String())
+	return sb.String()
+}
+
+// Matches returns true if the key press is the accelerator.
+func (a Accelerator) Matches(kp *KeyPressEvent) bool {
+	return kp.Key == a.Key && kp.Modifiers() == a.EventModifiers
+}
+
+// windowAccelerator is an accelerator registered on a Window.
+type windowAccelerator struct {
+	Accelerator
+	action func()
+}
+
+// AddAccelerator registers an accelerator on the window. When the accelerator
+// is pressed, action is called, before the event reaches the widgets.
+// Accelerators registered on the window have priority over those of the
+// menu items.
+func (w *Window) AddAccelerator(acc Accelerator, action func()) {
+	w.accelerators = append(w.accelerators, windowAccelerator{Accelerator: acc, action: action})
+}
+
+// RemoveAccelerator removes the accelerator from the window.
+func (w *Window) RemoveAccelerator(acc Accelerator) {
+	kept := w.accelerators[:0]
+	for _, wa := range w.accelerators {
+		if wa.Accelerator != acc {
+			kept = append(kept, wa)
+		}
+	}
+	w.accelerators = kept
+}
+
+// handleAccelerator calls the action of the accelerator of the key press, if
+// any. If a modal dialog is open, only the accelerators of its menu bar are
+// used. It returns true if the event was used.
+func (w *Window) handleAccelerator(e Event) bool {
+	kp, ok := e.(*KeyPressEvent)
+	if !ok {
+		return false
+	}
+
+	if pane := w.topPane(); pane != nil {
+		if pane.menuBar != nil && pane.menuBar.handleAccelerator(kp) {
+			return true
+		}
+		if pane.Modal() {
+			return false
+		}
+	}
+
+	for _, wa := range w.accelerators {
+		if wa.Matches(kp) {
+			if wa.action != nil {
+				wa.action()
+			}
+			return true
+		}
+	}
+
+	if w.menuBar != nil {
+		return w.menuBar.handleAccelerator(kp)
+	}
+	return false
+}
+
+// handleAccelerator activates the enabled menu item with the accelerator of
+// the key press, if any. It returns true if the event was used.
+func (b *MenuBar) handleAccelerator(kp *KeyPressEvent) bool {
+	for _, menu := range b.menus {
+		if !menu.Enabled() {
+			continue
+		}
+		for _, item := range menu.items {
+			if item.accelerator == nil || !item.accelerator.Matches(kp) {
+				continue
+			}
+			if !item.Enabled() {
+				// Disabled items do not fire.
+				continue
+			}
+			item.activate()
+			return true
+		}
+	}
+	return false
+}
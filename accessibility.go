@@ -0,0 +1,283 @@
+package ui
+
+import "fmt"
+
+// AccessibilityRole is the role of a widget in the accessibility tree.
+type AccessibilityRole string
+
+const (
+	RoleWidget    AccessibilityRole = "widget"
+	RoleWindow    AccessibilityRole = "window"
+	RolePane      AccessibilityRole = "pane"
+	RoleGroup     AccessibilityRole = "group"
+	RoleContainer AccessibilityRole = "container"
+	RoleButton    AccessibilityRole = "button"
+	RoleCheckbox  AccessibilityRole = "checkbox"
+	RoleRadio     AccessibilityRole = "radio"
+	RoleEntry     AccessibilityRole = "entry"
+	RoleNote      AccessibilityRole = "note"
+	RoleJournal   AccessibilityRole = "journal"
+	RoleText      AccessibilityRole = "text"
+	RoleDropdown  AccessibilityRole = "dropdown"
+	RoleSlider    AccessibilityRole = "slider"
+	RoleScrollbar AccessibilityRole = "scrollbar"
+	RoleScroll    AccessibilityRole = "scroll"
+	RoleTabs      AccessibilityRole = "tabs"
+	RoleTab       AccessibilityRole = "tab"
+	RoleMenuBar   AccessibilityRole = "menubar"
+	RoleMenu      AccessibilityRole = "menu"
+	RoleMenuItem  AccessibilityRole = "menuitem"
+	RoleSeparator AccessibilityRole = "separator"
+	RoleTable     AccessibilityRole = "table"
+	RoleColumn    AccessibilityRole = "column"
+	RoleTableCell AccessibilityRole = "cell"
+	RoleList      AccessibilityRole = "list"
+	RoleCard      AccessibilityRole = "card"
+	RolePicture   AccessibilityRole = "picture"
+	RoleMedia     AccessibilityRole = "media"
+)
+
+// AccessibilityNode describes a widget for assistive tools and test tools.
+// The bounds are absolute. It can be serialized to JSON.
+type AccessibilityNode struct {
+	Role     AccessibilityRole    `json:"role"`
+	Name     string               `json:"name,omitempty"`  // Name is the text or the caption of the widget.
+	Value    string               `json:"value,omitempty"` // Value is the value of the widget, such as the text of an Entry.
+	Checked  bool                 `json:"checked,omitempty"`
+	Disabled bool                 `json:"disabled,omitempty"`
+	Focused  bool                 `json:"focused,omitempty"`
+	Hidden   bool                 `json:"hidden,omitempty"`
+	X        int                  `json:"x"`
+	Y        int                  `json:"y"`
+	Width    int                  `json:"width"`
+	Height   int                  `json:"height"`
+	Children []*AccessibilityNode `json:"children,omitempty"`
+	Control  Control              `json:"-"` // Control is the described widget, if any.
+}
+
+// Accessible is implemented by widgets that describe themselves in the
+// accessibility tree. Accessibility is called with the node of the widget,
+// with the default role, name, state, bounds and children filled in, and
+// may change it.
+type Accessible interface {
+	Accessibility(node *AccessibilityNode)
+}
+
+// AccessibilityTree returns the accessibility tree of c and the widgets
+// below it. For a Window, the tree includes the menu bar and the dialogs.
+func AccessibilityTree(c Control) *AccessibilityNode {
+	focused := FocusedControl(controlWindow(c))
+	return accessibilityNode(c, focused, false)
+}
+
+// controlWindow returns the window that c is in, or c if it is not in one.
+func controlWindow(c Control) Control {
+	for parent := c; parent != nil; parent = parent.Parent() {
+		if _, ok := parent.(*Window); ok {
+			return parent
+		}
+	}
+	return c
+}
+
+func accessibilityNode(c Control, focused Control, hidden bool) *AccessibilityNode {
+	node := &AccessibilityNode{Control: c}
+	node.Role = accessibilityRole(c)
+	node.Name = accessibilityName(c)
+	node.Disabled = !c.Enabled()
+	node.Hidden = hidden || c.Hidden()
+	node.Focused = c == focused
+	node.X, node.Y = ControlAbsolute(c)
+	node.Width, node.Height = c.WidgetSize()
+
+	switch w := c.(type) {
+	case *Checkbox:
+		node.Checked = w.Checked()
+	case *Toggle:
+		node.Checked = w.Checked()
+	case *MenuItem:
+		node.Checked = w.Checked()
+	case *tabHeader:
+		node.Checked = w.pressed
+	case *Entry:
+		if w.entryKind != entryKindPassword {
+			node.Value = w.Text()
+		}
+	case *Note:
+		node.Value = w.Text()
+	case *Journal:
+		node.Value = w.Text()
+	case *Dropdown:
+		node.Value = w.Text()
+	case *Slider:
+		node.Value = fmt.Sprint(w.Value())
+	case *Scroller:
+		node.Value = fmt.Sprint(w.Value())
+	case *Roller:
+		node.Value = fmt.Sprint(w.Value())
+	}
+
+	for _, child := range accessibilityChildren(c) {
+		if child == nil {
+			continue
+		}
+		node.Children = append(node.Children, accessibilityNode(child, focused, node.Hidden))
+	}
+
+	if a, ok := c.(Accessible); ok {
+		a.Accessibility(node)
+	}
+	return node
+}
+
+func accessibilityChildren(c Control) []Control {
+	if w, ok := c.(*Window); ok {
+		var children []Control
+		if w.menuBar != nil {
+			children = append(children, w.menuBar)
+		}
+		if w.child != nil {
+			children = append(children, w.child)
+		}
+		if w.dialogs != nil {
+			children = append(children, w.dialogs.Children()...)
+		}
+		return children
+	}
+	return ControlChildren(c)
+}
+
+func accessibilityRole(c Control) AccessibilityRole {
+	switch w := c.(type) {
+	case *Window:
+		return RoleWindow
+	case *Pane:
+		return RolePane
+	case *Group:
+		return RoleGroup
+	case *Button:
+		return RoleButton
+	case *Checkbox:
+		return RoleCheckbox
+	case *Toggle:
+		return RoleRadio
+	case *Entry:
+		return RoleEntry
+	case *Note:
+		return RoleNote
+	case *Journal:
+		return RoleJournal
+	case *Label, *TextWidget, *IconTextWidget:
+		return RoleText
+	case *Dropdown:
+		return RoleDropdown
+	case *Slider:
+		return RoleSlider
+	case *Scroller, *Roller:
+		return RoleScrollbar
+	case *Overflow:
+		return RoleScroll
+	case *Tab:
+		return RoleTabs
+	case *tabHeader:
+		return RoleTab
+	case *MenuBar:
+		return RoleMenuBar
+	case *Menu:
+		return RoleMenu
+	case *MenuItem:
+		if w.kind == menuItemSeparator {
+			return RoleSeparator
+		}
+		return RoleMenuItem
+	case *Table:
+		return RoleTable
+	case *Column:
+		return RoleColumn
+	case *List:
+		return RoleList
+	case *Card:
+		return RoleCard
+	case *Picture:
+		return RolePicture
+	case *MediaPlayer:
+		return RoleMedia
+	case HasChildren:
+		return RoleContainer
+	}
+	return RoleWidget
+}
+
+func accessibilityName(c Control) string {
+	switch w := c.(type) {
+	case *Entry, *Note, *Journal, *Dropdown:
+		// The text of these is their value.
+		return ""
+	case *Card:
+		return w.caption.Text()
+	case *Column:
+		return w.caption.Text()
+	case interface{ Title() string }:
+		return w.Title()
+	case interface{ Text() string }:
+		return w.Text()
+	}
+	return ""
+}
+
+// Accessibility adds the visible cells of the column to the node.
+func (c *Column) Accessibility(node *AccessibilityNode) {
+	if c.table == nil {
+		return
+	}
+	dx, dy := node.X, node.Y
+	if !c.caption.Hidden() {
+		_, caph := c.caption.WidgetSize()
+		dy += caph
+	}
+	rows := c.table.NumRows()
+	rowh := c.table.RowHeight()
+	start := c.table.from
+	stop := rows
+	if c.table.shown > 0 && c.table.shown < rows {
+		stop = min(c.table.shown+start, rows)
+	}
+	for i := start; i < stop; i++ {
+		cell := &AccessibilityNode{Role: RoleTableCell, Hidden: node.Hidden}
+		cell.X, cell.Y, cell.Width, cell.Height = dx, dy, c.width, rowh
+		if row := c.table.FetchRow(i); row != nil {
+			switch value := row.Value(c.index).(type) {
+			case nil, *Graphic:
+			case bool:
+				cell.Checked = value
+			default:
+				cell.Name = fmt.Sprint(value)
+			}
+		}
+		node.Children = append(node.Children, cell)
+		dy += rowh
+	}
+}
+
+// Find returns the first node in the tree, depth first, for which match
+// returns true, or nil if there is none.
+func (n *AccessibilityNode) Find(match func(*AccessibilityNode) bool) *AccessibilityNode {
+	if match(n) {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// FindRole returns the first node in the tree with the given role and name.
+func (n *AccessibilityNode) FindRole(role AccessibilityRole, name string) *AccessibilityNode {
+	return n.Find(func(node *AccessibilityNode) bool {
+		return node.Role == role && node.Name == name
+	})
+}
+
+var _ Accessible = &Column{}
@@ -4,6 +4,7 @@ import "context"
 import "fmt"
 import "image"
 import "image/color"
+import "math"
 
 import "golang.org/x/image/font"
 import "golang.org/x/image/font/opentype"
@@ -11,10 +12,6 @@ import "golang.org/x/image/math/fixed"
 
 import "github.com/hajimehoshi/ebiten/v2"
 import "github.com/hajimehoshi/ebiten/v2/exp/textinput"
-import "github.com/hajimehoshi/ebiten/v2/text"
-
-// The vector package is convenient but unfortunately quite low in performance.
-import "github.com/hajimehoshi/ebiten/v2/vector"
 
 import "golang.design/x/clipboard"
 
@@ -150,28 +147,23 @@ func FixedHeight(r fixed.Rectangle26_6) int {
 }
 
 func FillRect(g *Graphic, x, y, w, h int, color Color) {
-	vector.DrawFilledRect(g, float32(x), float32(y),
-		float32(w), float32(h), color, true)
+	GraphicRenderer(g).FillRect(x, y, w, h, color)
 }
 
 func StrokeRect(g *Graphic, x, y, w, h, t int, color Color) {
-	vector.StrokeRect(g, float32(x), float32(y),
-		float32(w), float32(h), float32(t), color, true)
+	GraphicRenderer(g).StrokeRect(x, y, w, h, t, color)
 }
 
 func StrokeLine(dst *Graphic, x, y, w, h, t int, color Color) {
-	vector.StrokeLine(dst, float32(x), float32(y),
-		float32(x+w), float32(y+h), float32(t), color, true)
+	GraphicRenderer(dst).StrokeLine(x, y, w, h, t, color)
 }
 
 func StrokeCircle(dst *Graphic, cx, cy, r, t int, color Color) {
-	vector.StrokeCircle(dst, float32(cx), float32(cy),
-		float32(r), float32(t), color, true)
+	GraphicRenderer(dst).StrokeCircle(cx, cy, r, t, color)
 }
 
 func FillCircle(dst *Graphic, cx, cy, r int, color Color) {
-	vector.DrawFilledCircle(dst, float32(cx), float32(cy),
-		float32(r), color, true)
+	GraphicRenderer(dst).FillCircle(cx, cy, r, color)
 }
 
 func FillFrame(g *Graphic, x, y, w, h, thick int, fill, border Color) {
@@ -211,11 +203,13 @@ func DrawDebug(dst *Graphic, x, y, w, h int, form string, args ...any) {
 
 	y += textFaceDebug.Metrics().Ascent.Round()
 	str := fmt.Sprintf(form, args...)
-	text.Draw(dst, str, textFaceDebug, x, y, textColorDebug)
+	TextDraw(dst, str, textFaceDebug, x, y, textColorDebug)
 }
 
+// TextDraw draws str with y as the base line. Right to left text, such as
+// Arabic and Hebrew, is reordered for display line by line.
 func TextDraw(dst *Graphic, str string, face Face, x, y int, col Color) {
-	text.Draw(dst, str, face, x, y, col)
+	GraphicRenderer(dst).DrawText(bidiVisualLines(str), face, x, y, col)
 }
 
 func TextDrawStyle(dst *Graphic, str string, x, y int, style Style) {
@@ -258,8 +252,13 @@ func GraphicClipStyle(dst *Graphic, x, y, w, h int, style Style) *Graphic {
 	y += margin
 	w -= margin * 2
 	h -= margin * 2
-	rect := image.Rect(x, y, x+w, y+h)
-	return dst.SubImage(rect).(*ebiten.Image)
+	return GraphicClip(dst, x, y, w, h)
+}
+
+// GraphicClip returns a graphic to draw on dst that clips the drawing to the
+// given rectangle. The coordinates on the clip are the same as on dst.
+func GraphicClip(dst *Graphic, x, y, w, h int) *Graphic {
+	return GraphicRenderer(dst).Clip(x, y, w, h).Target()
 }
 
 func TextDrawHeight(dst *Graphic, str string, face Face, x, y int, col Color) {
@@ -285,10 +284,7 @@ func TextDrawHeightStyle(dst *Graphic, str string, x, y int, style Style) {
 }
 
 func FillRectClip(g *Graphic, x, y, w, h int, col Color) {
-	rect := image.Rect(x, y, x+w, y+h)
-	sub := g.SubImage(rect).(*ebiten.Image)
-	sub.Fill(col)
-	sub.Dispose()
+	GraphicRenderer(g).Clip(x, y, w, h).Fill(col)
 }
 
 func FillFrameStyle(g *Graphic, x, y, w, h int, style Style) {
@@ -312,6 +308,9 @@ func DrawFrameOptionalStyle(g *Graphic, x, y, w, h int, style *Style) {
 type TextInputState = textinput.State
 
 func StartTextInput(x, y int) (states chan TextInputState, close func()) {
+	if headless {
+		return nil, nil
+	}
 	return textinput.Start(x, y)
 }
 
@@ -348,7 +347,9 @@ func WatchClipboard(ctx context.Context, format ClipboardFormat) <-chan []byte {
 }
 
 func NewGraphicFromImage(img Image) *Graphic {
-	return ebiten.NewImageFromImage(img)
+	g := ebiten.NewImageFromImage(img)
+	recordGraphicSource(g, img)
+	return g
 }
 
 func NewGraphic(width, height int) *Graphic {
@@ -356,16 +357,15 @@ func NewGraphic(width, height int) *Graphic {
 }
 
 func DrawGraphicAt(target *Graphic, source *Graphic, x, y int) {
-	opts := ebiten.DrawImageOptions{}
-	opts.GeoM.Translate(float64(x), float64(y))
-	target.DrawImage(source, &opts)
+	w, h := graphicSize(source)
+	GraphicRenderer(target).DrawGraphic(source, x, y, w, h, colorWhite)
 }
 
 func DrawGraphicAtScale(target *Graphic, source *Graphic, x, y int, sx, sy float64) {
-	opts := ebiten.DrawImageOptions{}
-	opts.GeoM.Scale(sx, sy)
-	opts.GeoM.Translate(float64(x), float64(y))
-	target.DrawImage(source, &opts)
+	w, h := graphicSize(source)
+	w = int(math.Round(float64(w) * sx))
+	h = int(math.Round(float64(h) * sy))
+	GraphicRenderer(target).DrawGraphic(source, x, y, w, h, colorWhite)
 }
 
 func DrawLineStyle(g *Graphic, x, y, w, h int, style Style) {
@@ -420,5 +420,8 @@ func CursorShape() CursorShapeType {
 }
 
 func SetCursorShape(shape ebiten.CursorShapeType) {
+	if headless {
+		return
+	}
 	ebiten.SetCursorShape(shape)
 }
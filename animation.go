@@ -0,0 +1,237 @@
+package ui
+
+import "math"
+import "golang.org/x/exp/slices"
+import "time"
+
+// Easing maps the progress of an animation, from 0 to 1, to the progress of
+// the animated value, also from 0 at the start to 1 at the end.
+type Easing func(t float64) float64
+
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// The durations of the animations of the widgets.
+const (
+	animationShort  = 120 * time.Millisecond
+	animationMedium = 200 * time.Millisecond
+)
+
+// Animation changes a value over time, driven by the UpdateEvent of the
+// window. Like the widgets, animations may only be used on the UI
+// goroutine. Use AnimateInt, AnimateFloat or AnimateColor to animate a
+// property of a widget, or NewAnimation for anything else, and Invalidates
+// to tell which widget the animation changes.
+type Animation struct {
+	duration time.Duration
+	elapsed  time.Duration
+	easing   Easing
+	step     func(t float64)
+	onDone   func()
+	running  bool
+	widget   Control // widget is the animated widget, if any.
+}
+
+var (
+	animations    []*Animation
+	reducedMotion bool
+)
+
+// SetReducedMotion turns off all animations if reduced is true, for users
+// who prefer reduced motion. Animations then jump to their end at once.
+func SetReducedMotion(reduced bool) {
+	reducedMotion = reduced
+	if reduced {
+		for _, a := range slices.Clone(animations) {
+			a.Finish()
+		}
+	}
+}
+
+// ReducedMotion returns whether the animations are turned off.
+func ReducedMotion() bool {
+	return reducedMotion
+}
+
+// Animating returns whether any animation is running.
+func Animating() bool {
+	return len(animations) > 0
+}
+
+// NewAnimation returns an animation that calls step on every update while
+// it runs, with the eased progress from 0 to 1. The last call is always
+// with 1. The animation must be started with Start. If easing is nil,
+// EaseLinear is used.
+func NewAnimation(duration time.Duration, easing Easing, step func(t float64)) *Animation {
+	if easing == nil {
+		easing = EaseLinear
+	}
+	return &Animation{duration: duration, easing: easing, step: step}
+}
+
+// AnimateInt returns an animation that calls set with values from from to to.
+func AnimateInt(from, to int, duration time.Duration, easing Easing, set func(int)) *Animation {
+	return NewAnimation(duration, easing, func(t float64) {
+		set(lerpInt(from, to, t))
+	})
+}
+
+// AnimateFloat returns an animation that calls set with values from from to
+// to.
+func AnimateFloat(from, to float64, duration time.Duration, easing Easing, set func(float64)) *Animation {
+	return NewAnimation(duration, easing, func(t float64) {
+		set(from + (to-from)*t)
+	})
+}
+
+// AnimateColor returns an animation that calls set with colors that blend
+// from from to to.
+func AnimateColor(from, to StyleColor, duration time.Duration, easing Easing, set func(StyleColor)) *Animation {
+	return NewAnimation(duration, easing, func(t float64) {
+		set(lerpColor(from, to, t))
+	})
+}
+
+func lerpInt(from, to int, t float64) int {
+	return from + int(math.Round(float64(to-from)*t))
+}
+
+func lerpByte(from, to byte, t float64) byte {
+	return byte(lerpInt(int(from), int(to), t))
+}
+
+func lerpColor(from, to StyleColor, t float64) StyleColor {
+	return StyleColor{
+		R: lerpByte(from.R, to.R, t),
+		G: lerpByte(from.G, to.G, t),
+		B: lerpByte(from.B, to.B, t),
+		A: lerpByte(from.A, to.A, t),
+	}
+}
+
+// OnDone sets a function that is called when the animation reaches its end.
+// It is not called if the animation is stopped.
+func (a *Animation) OnDone(f func()) *Animation {
+	a.onDone = f
+	return a
+}
+
+// Invalidates sets the widget that the animation changes. After each step
+// the caches of that widget are invalidated, see Invalidate, so only the
+// caches that contain the widget are drawn again while the animation runs.
+func (a *Animation) Invalidates(c Control) *Animation {
+	a.widget = c
+	return a
+}
+
+// invalidate invalidates the animated widget, or only requests a redraw if
+// the animation has no widget.
+func (a *Animation) invalidate() {
+	if a.widget != nil {
+		Invalidate(a.widget)
+	} else {
+		RequestRedraw()
+	}
+}
+
+// Start starts the animation from the beginning. With reduced motion, the
+// animation ends at once.
+func (a *Animation) Start() *Animation {
+	a.elapsed = 0
+	if reducedMotion || a.duration <= 0 {
+		a.Finish()
+		return a
+	}
+	if !a.running {
+		a.running = true
+		animations = append(animations, a)
+	}
+	a.step(a.easing(0))
+	a.invalidate()
+	return a
+}
+
+// Stop stops the animation where it is.
+func (a *Animation) Stop() {
+	if !a.running {
+		return
+	}
+	a.running = false
+	animations = slices.DeleteFunc(animations, func(b *Animation) bool {
+		return a == b
+	})
+}
+
+// Finish stops the animation at its end.
+func (a *Animation) Finish() {
+	a.Stop()
+	a.elapsed = a.duration
+	a.step(1)
+	a.invalidate()
+	if a.onDone != nil {
+		a.onDone()
+	}
+}
+
+// Running returns whether the animation is running.
+func (a *Animation) Running() bool {
+	return a != nil && a.running
+}
+
+// advance advances the animation by elapsed.
+func (a *Animation) advance(elapsed time.Duration) {
+	a.elapsed += elapsed
+	if a.elapsed >= a.duration {
+		a.Finish()
+		return
+	}
+	a.step(a.easing(float64(a.elapsed) / float64(a.duration)))
+	a.invalidate()
+}
+
+// runAnimations advances the running animations. It is called by the window
+// for every UpdateEvent.
+func runAnimations(elapsed time.Duration) {
+	if len(animations) == 0 {
+		return
+	}
+	for _, a := range slices.Clone(animations) {
+		if a.running {
+			a.advance(elapsed)
+		}
+	}
+}
+
+// stopAnimation stops a if it is not nil.
+func stopAnimation(a *Animation) {
+	if a != nil {
+		a.Stop()
+	}
+}
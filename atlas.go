@@ -48,11 +48,7 @@ func (a Atlas) FindGraphic(name string) *Graphic {
 
 func (a Atlas) DrawSprite(dst *Graphic, x, y, w, h int, name string) {
 	if sub, ok := a.ByName[name]; ok {
-		opts := ebiten.DrawImageOptions{}
-		sx, sy := sub.Image.Size()
-		opts.GeoM.Scale(float64(w)/float64(sx), float64(h)/float64(sy))
-		opts.GeoM.Translate(float64(x), float64(y))
-		dst.DrawImage(sub.Image, &opts)
+		GraphicRenderer(dst).DrawGraphic(sub.Image, x, y, w, h, colorWhite)
 	} else {
 		FillFrame(dst, x, y, w, h, 1, theme.Error.Fill.Color.RGBA(), theme.Error.Fill.Color.RGBA())
 		dprintln("Warning: no such sprite", name)
@@ -93,7 +89,7 @@ func loadAtlas(name string) *Atlas {
 	for _, sprite := range atlas.Sprites {
 		key := sprite.Name
 		rect := image.Rect(sprite.X, sprite.Y, sprite.X+sprite.Width, sprite.Y+sprite.Height)
-		value := atlas.Image.SubImage(rect).(*ebiten.Image)
+		value := SubGraphic(atlas.Image, rect)
 		sprite.Image = value
 		atlas.ByName[key] = sprite
 	}
@@ -126,7 +122,7 @@ func (atlas *Atlas) NineSliceSprite(border int, sprite *AtlasSprite) {
 		dw := wtab[ix]
 		dh := htab[iy]
 		rect = image.Rect(dx, dy, dx+dw, dy+dh)
-		slice := atlas.Image.SubImage(rect).(*ebiten.Image)
+		slice := SubGraphic(atlas.Image, rect)
 		sprite.Slice[i] = slice
 	}
 	sprite.NineSlice.Border = border
@@ -140,17 +136,7 @@ func (atlas *Atlas) NineSlice(border int) {
 }
 
 func DrawSpriteAtScaleColor(dst, src *Graphic, x, y, w, h int, col Color) {
-	opts := ebiten.DrawImageOptions{}
-	sx, sy := src.Size()
-	opts.GeoM.Scale(float64(w)/float64(sx), float64(h)/float64(sy))
-	opts.GeoM.Translate(float64(x), float64(y))
-	r, g, b, a := col.RGBA()
-	rf := float32(r) / float32(0xffff)
-	gf := float32(g) / float32(0xffff)
-	bf := float32(b) / float32(0xffff)
-	af := float32(a) / float32(0xffff)
-	opts.ColorScale.Scale(rf, gf, bf, af)
-	dst.DrawImage(src, &opts)
+	GraphicRenderer(dst).DrawGraphic(src, x, y, w, h, col)
 }
 
 func (n NineSlice) OK() bool {
@@ -88,6 +88,10 @@ type BasicWidget struct {
 	customStyle  *Style
 	sub          *Image // sub image for clipping
 	floating     Control
+	focusable    bool            // focusable is true if the widget can get the focus by navigation.
+	tabOrder     int             // tabOrder is the position of the widget in the focus chain.
+	scope        layoutScope     // scope is used to lay out the widget again on its own.
+	direction    LayoutDirection // direction is the set layout direction.
 }
 
 type DialogStarter interface {
@@ -99,7 +103,41 @@ func (w BasicWidget) Focus() Control {
 }
 
 func (w *BasicWidget) SetFocus(focused Control) {
+	if w.focused == focused {
+		return
+	}
+	// Both the old and the new focus change how they are drawn.
+	if w.focused != nil {
+		Invalidate(w.focused)
+	}
 	w.focused = focused
+	if focused != nil {
+		Invalidate(focused)
+	}
+}
+
+// Focusable returns true if the widget can get the focus by keyboard or
+// gamepad navigation.
+func (w BasicWidget) Focusable() bool {
+	return w.focusable
+}
+
+// SetFocusable sets whether the widget can get the focus by keyboard or
+// gamepad navigation.
+func (w *BasicWidget) SetFocusable(focusable bool) {
+	w.focusable = focusable
+}
+
+// TabOrder returns the tab order of the widget. See SetTabOrder.
+func (w BasicWidget) TabOrder() int {
+	return w.tabOrder
+}
+
+// SetTabOrder sets the tab order of the widget. Widgets with a positive tab
+// order come first in the focus chain, in ascending tab order. The other
+// widgets follow in the order of the widget tree.
+func (w *BasicWidget) SetTabOrder(order int) {
+	w.tabOrder = order
 }
 
 func (w BasicWidget) Parent() Control {
@@ -116,11 +154,13 @@ func (w *BasicWidget) Toplevel() bool {
 
 func (w *BasicWidget) Show() {
 	w.wantHidden = false
+	Invalidate(w)
 }
 
 func (w *BasicWidget) Hide() {
 	w.wantHidden = true
 	UnfocusParentIfNeeded(w)
+	Invalidate(w)
 }
 
 func (w *BasicWidget) Hidden() bool {
@@ -133,10 +173,12 @@ func (w *BasicWidget) Enabled() bool {
 
 func (w *BasicWidget) Enable() {
 	w.wantDisabled = false
+	Invalidate(w)
 }
 
 func (w *BasicWidget) Disable() {
 	w.wantDisabled = true
+	Invalidate(w)
 }
 
 // UnfocusParentIfNeeded, will, if the widget was focused by a parent,
@@ -185,6 +227,7 @@ func (w *BasicWidget) GrowToStyleSize() {
 func (w *BasicWidget) ClipTo(width, height int) {
 	w.wfull = w.width
 	w.hfull = w.height
+	w.scope.constrain(width, height)
 
 	if width > 0 && w.width > width {
 		w.width = width
@@ -306,6 +349,7 @@ func (w BasicWidget) Style() Style {
 
 func (w *BasicWidget) SetStyle(style *Style) {
 	w.customStyle = style
+	Invalidate(w)
 }
 
 func (w BasicWidget) WidgetLayer() int {
@@ -345,10 +389,16 @@ func MoveWidgetBy(w Control, dx, dy int) {
 	w.MoveWidget(dx+ox, dy+oy)
 }
 
+// NeedLayout marks the control as needing to be laid out again, for example
+// after its contents changed. Before the next frame, the window lays out only
+// the control again, with the same available size as before. Only if the size
+// of the control changed, its parent is laid out again as well, and so on up
+// to the window.
 func NeedLayout(control Control) {
 	for parent := control; parent != nil; parent = parent.Parent() {
 		if window, ok := parent.(*Window); ok {
-			window.Relayout()
+			window.needLayoutOf(control)
+			return
 		}
 	}
 }
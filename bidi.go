@@ -0,0 +1,218 @@
+package ui
+
+import "math"
+import "strings"
+import "unicode/utf8"
+
+import "golang.org/x/exp/slices"
+
+import "golang.org/x/text/unicode/bidi"
+
+// bidiRightToLeft returns whether r is a strong right to left character,
+// such as an Arabic or Hebrew letter.
+func bidiRightToLeft(r rune) bool {
+	props, _ := bidi.LookupRune(r)
+	class := props.Class()
+	return class == bidi.R || class == bidi.AL
+}
+
+// hasRightToLeft returns whether text contains right to left characters.
+func hasRightToLeft(text string) bool {
+	for _, r := range text {
+		// Fast path, no right to left scripts before Hebrew.
+		if r >= 0x0590 && bidiRightToLeft(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// textRightToLeft returns whether the paragraph direction of text is right
+// to left, which depends on its first strong character. Text without strong
+// characters, such as numbers, is right to left if rightToLeft is true.
+func textRightToLeft(text string, rightToLeft bool) bool {
+	for _, r := range text {
+		props, _ := bidi.LookupRune(r)
+		switch props.Class() {
+		case bidi.L:
+			return false
+		case bidi.R, bidi.AL:
+			return true
+		}
+	}
+	return rightToLeft
+}
+
+// bidiVisual returns a line of text in visual order, so it can be drawn from
+// left to right. The embedding levels of the characters are resolved with
+// the Unicode bidirectional algorithm, and then reordered by rule L2. Text
+// without right to left characters is returned as it is.
+func bidiVisual(line string) string {
+	if !hasRightToLeft(line) || !utf8.ValidString(line) {
+		return line
+	}
+	levels := bidiLevels(line, textRightToLeft(line, false))
+	if levels == nil {
+		return line
+	}
+	return string(bidiReorder([]rune(line), levels))
+}
+
+// bidiLevels returns the embedding level of each rune of a line, for a left
+// to right paragraph, or a right to left one if rightToLeft is true. It
+// returns nil if the levels can't be resolved.
+//
+// The bidi package only reports the direction of the runs, so the levels
+// are derived from that. In a right to left paragraph, right to left
+// characters have level 1 and left to right ones level 2. In a left to
+// right paragraph, right to left characters have level 1, and left to right
+// ones level 0, except for numbers that follow right to left text, which
+// have level 2 by rules W7 and I1. Explicit embeddings are not supported.
+func bidiLevels(line string, rightToLeft bool) []int {
+	var opts []bidi.Option
+	if rightToLeft {
+		opts = append(opts, bidi.DefaultDirection(bidi.RightToLeft))
+	}
+	var paragraph bidi.Paragraph
+	if _, err := paragraph.SetString(line, opts...); err != nil {
+		return nil
+	}
+	ordering, err := paragraph.Order()
+	if err != nil {
+		return nil
+	}
+
+	runes := []rune(line)
+	levels := make([]int, 0, len(runes))
+	for i := 0; i < ordering.NumRuns(); i++ {
+		run := ordering.Run(i)
+		level := 0
+		if run.Direction() == bidi.RightToLeft {
+			level = 1
+		} else if rightToLeft {
+			level = 2
+		}
+		for range []rune(run.String()) {
+			levels = append(levels, level)
+		}
+	}
+	if len(levels) != len(runes) {
+		return nil
+	}
+	if !rightToLeft {
+		bidiNumberLevels(runes, levels)
+	}
+	return levels
+}
+
+// bidiNumberLevels raises the level of the numbers in the left to right runs
+// of a left to right paragraph to 2, if they follow right to left text. The
+// separators and terminators of such a number, as in 1,5 or 5%, and the
+// marks on it get the level of the number too.
+func bidiNumberLevels(runes []rune, levels []int) {
+	classes := make([]bidi.Class, len(runes))
+	strong := bidi.L // strong is the last strong class, L at the start.
+	for i, r := range runes {
+		props, _ := bidi.LookupRune(r)
+		classes[i] = props.Class()
+		switch classes[i] {
+		case bidi.L:
+			strong = bidi.L
+		case bidi.R, bidi.AL:
+			strong = bidi.R
+		case bidi.EN:
+			if levels[i] == 0 && strong == bidi.R {
+				levels[i] = 2
+			}
+		case bidi.AN:
+			if levels[i] == 0 {
+				levels[i] = 2
+			}
+		}
+	}
+	number := func(i int) bool {
+		return i >= 0 && i < len(runes) && levels[i] == 2 &&
+			(classes[i] == bidi.EN || classes[i] == bidi.AN)
+	}
+	for i := range runes {
+		if levels[i] != 0 {
+			continue
+		}
+		switch classes[i] {
+		case bidi.CS, bidi.ES:
+			// A single separator between two numbers, by rule W4.
+			if number(i-1) && number(i+1) {
+				levels[i] = 2
+			}
+		case bidi.NSM:
+			// A mark has the level of the character before it, by rule W1.
+			if i > 0 {
+				levels[i] = levels[i-1]
+			}
+		}
+	}
+	// Terminators next to a number, by rule W5.
+	for i := range runes {
+		if classes[i] != bidi.ET || levels[i] != 0 {
+			continue
+		}
+		end := i
+		for end < len(runes) && classes[end] == bidi.ET {
+			end++
+		}
+		if number(i-1) || number(end) {
+			for j := i; j < end; j++ {
+				levels[j] = 2
+			}
+		}
+	}
+}
+
+// bidiReorder returns the runes in visual order for their levels, by rule
+// L2: from the highest level down to the lowest odd level, each sequence of
+// runes at that level or higher is reversed. Brackets and other mirrored
+// characters at an odd level are mirrored, by rule L4.
+func bidiReorder(runes []rune, levels []int) []rune {
+	visual := slices.Clone(runes)
+	order := slices.Clone(levels)
+	highest, lowestOdd := 0, math.MaxInt
+	for _, level := range levels {
+		highest = max(highest, level)
+		if level%2 == 1 {
+			lowestOdd = min(lowestOdd, level)
+		}
+	}
+	for i, r := range visual {
+		if levels[i]%2 == 1 {
+			visual[i] = []rune(bidi.ReverseString(string(r)))[0]
+		}
+	}
+	for level := highest; level >= lowestOdd && level > 0; level-- {
+		for start := 0; start < len(visual); {
+			if order[start] < level {
+				start++
+				continue
+			}
+			end := start
+			for end < len(visual) && order[end] >= level {
+				end++
+			}
+			slices.Reverse(visual[start:end])
+			slices.Reverse(order[start:end])
+			start = end
+		}
+	}
+	return visual
+}
+
+// bidiVisualLines returns text in visual order, line by line.
+func bidiVisualLines(text string) string {
+	if !hasRightToLeft(text) {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = bidiVisual(line)
+	}
+	return strings.Join(lines, "\n")
+}
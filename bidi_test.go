@@ -0,0 +1,120 @@
+package ui
+
+import "testing"
+
+import "golang.org/x/exp/slices"
+
+func TestHasRightToLeft(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"", false},
+		{"hello", false},
+		{"日本語", false},
+		{"abc אבג", true},
+		{"مرحبا", true},
+		{"123", false},
+	}
+	for _, tt := range tests {
+		if got := hasRightToLeft(tt.text); got != tt.want {
+			t.Errorf("hasRightToLeft(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTextRightToLeft(t *testing.T) {
+	tests := []struct {
+		text        string
+		rightToLeft bool
+		want        bool
+	}{
+		{"abc אבג", true, false},
+		{"אבג abc", false, true},
+		{"123 مرحبا", false, true},
+		{"123", false, false},
+		{"123", true, true},
+		{"", true, true},
+	}
+	for _, tt := range tests {
+		if got := textRightToLeft(tt.text, tt.rightToLeft); got != tt.want {
+			t.Errorf("textRightToLeft(%q, %v) = %v, want %v", tt.text, tt.rightToLeft, got, tt.want)
+		}
+	}
+}
+
+func TestBidiLevels(t *testing.T) {
+	tests := []struct {
+		line        string
+		rightToLeft bool
+		want        []int
+	}{
+		{"abc", false, []int{0, 0, 0}},
+		{"אבג", true, []int{1, 1, 1}},
+		{"ab אב", false, []int{0, 0, 0, 1, 1}},
+		{"אב ab", true, []int{1, 1, 1, 2, 2}},
+		{"a א 12 ב b", false, []int{0, 0, 1, 1, 2, 2, 1, 1, 0, 0}},
+		{"a 12", false, []int{0, 0, 0, 0}},
+		{"א 5%", false, []int{1, 1, 2, 2}},
+		{"א 1,5", true, []int{1, 1, 2, 2, 2}},
+	}
+	for _, tt := range tests {
+		if got := bidiLevels(tt.line, tt.rightToLeft); !slices.Equal(got, tt.want) {
+			t.Errorf("bidiLevels(%q, %v) = %v, want %v", tt.line, tt.rightToLeft, got, tt.want)
+		}
+	}
+}
+
+func TestBidiReorder(t *testing.T) {
+	tests := []struct {
+		text   string
+		levels []int
+		want   string
+	}{
+		{"abcd", []int{0, 0, 0, 0}, "abcd"},
+		{"abcd", []int{0, 1, 1, 0}, "acbd"},
+		{"abcd", []int{1, 1, 2, 2}, "cdba"},
+		{"abcde", []int{0, 1, 2, 1, 0}, "adcbe"},
+		{"(a)", []int{1, 1, 1}, "(a)"},
+		{"a(b", []int{0, 1, 1}, "ab)"},
+	}
+	for _, tt := range tests {
+		if got := string(bidiReorder([]rune(tt.text), tt.levels)); got != tt.want {
+			t.Errorf("bidiReorder(%q, %v) = %q, want %q", tt.text, tt.levels, got, tt.want)
+		}
+	}
+}
+
+func TestBidiVisual(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"", ""},
+		{"hello", "hello"},
+		{"אבג", "גבא"},
+		{"אבג דהו", "והד גבא"},
+		{"abc אבג def", "abc גבא def"},
+		{"abc אבג 123 דהו def", "abc והד 123 גבא def"},
+		{"abc אבג 50% def", "abc 50% גבא def"},
+		{"אבג abc דהו", "והד abc גבא"},
+		{"אבג 123", "123 גבא"},
+		{"אבג 1,5 דהו", "והד 1,5 גבא"},
+		{"(אבג)", "(גבא)"},
+		{"abc (אבג) def", "abc (גבא) def"},
+		{"مرحبا بالعالم", "ملاعلاب ابحرم"},
+		{"مرحبا ١٢٣", "١٢٣ ابحرم"},
+	}
+	for _, tt := range tests {
+		if got := bidiVisual(tt.line); got != tt.want {
+			t.Errorf("bidiVisual(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestBidiVisualLines(t *testing.T) {
+	got := bidiVisualLines("abc\nאבג דהו\n")
+	if want := "abc\nוהד גבא\n"; got != want {
+		t.Errorf("bidiVisualLines = %q, want %q", got, want)
+	}
+}
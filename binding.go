@@ -0,0 +1,168 @@
+package ui
+
+import "golang.org/x/exp/slices"
+
+// Binding is an observable value that can be bound to widgets. When the
+// value changes, the subscribers are called with the new value. Widgets
+// that are bound to a Binding show its value, and set it when the user
+// changes them. Setting a value that is equal to the current value does
+// nothing, so a binding and its widgets can't update each other in a loop.
+type Binding[T comparable] struct {
+	value       T
+	subscribers []*bindingSubscriber[T]
+}
+
+type bindingSubscriber[T comparable] struct {
+	cb func(T)
+}
+
+// NewBinding returns a binding with the given initial value.
+func NewBinding[T comparable](value T) *Binding[T] {
+	return &Binding[T]{value: value}
+}
+
+// Get returns the value.
+func (b *Binding[T]) Get() T {
+	return b.value
+}
+
+// Set sets the value and calls the subscribers if it changed.
+func (b *Binding[T]) Set(value T) {
+	if b.value == value {
+		return
+	}
+	b.value = value
+	// Iterate over a copy, subscribers may subscribe or unsubscribe.
+	for _, sub := range slices.Clone(b.subscribers) {
+		if sub.cb != nil {
+			sub.cb(value)
+		}
+	}
+}
+
+// Subscribe calls cb with the new value each time the value changes.
+// It returns a function that ends the subscription.
+func (b *Binding[T]) Subscribe(cb func(T)) (unsubscribe func()) {
+	sub := &bindingSubscriber[T]{cb: cb}
+	b.subscribers = append(b.subscribers, sub)
+	return func() {
+		sub.cb = nil
+		b.subscribers = slices.DeleteFunc(b.subscribers, func(s *bindingSubscriber[T]) bool {
+			return s == sub
+		})
+	}
+}
+
+// ConvertBinding returns a binding of type U that follows b. to converts the
+// values of b to U. from converts them back, and may return an error if the
+// value can't be converted, for example text that is not a number, in which
+// case b is not changed. If from is nil the converted binding is read only.
+// It also returns a function that stops the converted binding from following
+// b, after which b no longer refers to it.
+func ConvertBinding[T, U comparable](b *Binding[T], to func(T) U, from func(U) (T, error)) (conv *Binding[U], unsubscribe func()) {
+	conv = NewBinding(to(b.Get()))
+	unsubscribeTo := b.Subscribe(func(value T) {
+		conv.Set(to(value))
+	})
+	unsubscribeFrom := func() {}
+	if from != nil {
+		unsubscribeFrom = conv.Subscribe(func(value U) {
+			if converted, err := from(value); err == nil {
+				b.Set(converted)
+			}
+		})
+	}
+	return conv, func() {
+		unsubscribeTo()
+		unsubscribeFrom()
+	}
+}
+
+// widgetBinding links a widget to a Binding. syncing is set while the value
+// is copied in either direction, so the copy doesn't come back.
+type widgetBinding struct {
+	update      func()
+	unsubscribe func()
+	syncing     bool
+}
+
+// bindWidget links w to b, or unlinks it if b is nil. get and set get and
+// set the value of the widget. The widget gets the value of b.
+func bindWidget[T comparable](w *widgetBinding, b *Binding[T], get func() T, set func(T)) {
+	w.unbind()
+	if b == nil {
+		return
+	}
+	w.sync(func() { set(b.Get()) })
+	w.update = func() { b.Set(get()) }
+	w.unsubscribe = b.Subscribe(func(value T) {
+		w.sync(func() { set(value) })
+	})
+}
+
+func (w *widgetBinding) unbind() {
+	if w.unsubscribe != nil {
+		w.unsubscribe()
+	}
+	w.update = nil
+	w.unsubscribe = nil
+}
+
+func (w *widgetBinding) sync(copy func()) {
+	if w.syncing {
+		return
+	}
+	w.syncing = true
+	defer func() { w.syncing = false }()
+	copy()
+}
+
+// changed copies the value of the widget to the binding, if any. It is
+// called by the widget after the user may have changed it.
+func (w *widgetBinding) changed() {
+	if w.update != nil {
+		w.sync(w.update)
+	}
+}
+
+// Bind shows the value of b in the entry, and sets b when the user edits
+// the entry. Bind(nil) unbinds the entry.
+func (e *Entry) Bind(b *Binding[string]) {
+	bindWidget(&e.binding, b, func() string { return e.Text() }, e.SetText)
+}
+
+// Bind shows the value of b in the note, and sets b when the user edits
+// the note. Bind(nil) unbinds the note.
+func (e *Note) Bind(b *Binding[string]) {
+	bindWidget(&e.binding, b, e.Text, e.SetText)
+}
+
+// Bind checks the checkbox if b is true, and sets b when the user clicks
+// the checkbox. Bind(nil) unbinds the checkbox.
+func (b *Checkbox) Bind(bb *Binding[bool]) {
+	bindWidget(&b.binding, bb, b.Checked, b.SetChecked)
+}
+
+// Bind selects the toggle with the index in b, and sets b when another
+// toggle is selected. Bind(nil) unbinds the radio.
+func (r *Radio) Bind(b *Binding[int]) {
+	bindWidget(&r.binding, b, func() int { return r.Selected() }, r.SetSelected)
+}
+
+// Bind sets the value of the slider to b, and sets b when the user moves
+// the slider. Bind(nil) unbinds the slider.
+func (s *Slider) Bind(b *Binding[int]) {
+	bindWidget(&s.binding, b, func() int { return s.Value() }, s.SetValue)
+}
+
+// Bind selects the item with the index in b, and sets b when the user
+// selects another item. Bind(nil) unbinds the dropdown.
+func (d *Dropdown) Bind(b *Binding[int]) {
+	bindWidget(&d.binding, b, func() int { return d.committed }, d.SetSelected)
+}
+
+// Bind shows the value of b in the label. A label can't be edited so the
+// binding only goes one way. Bind(nil) unbinds the label.
+func (l *Label) Bind(b *Binding[string]) {
+	bindWidget(&l.binding, b, l.Text, l.SetText)
+}
@@ -0,0 +1,37 @@
+package ui
+
+import "strconv"
+import "testing"
+
+func TestConvertBinding(t *testing.T) {
+	b := NewBinding(1)
+	conv, unsubscribe := ConvertBinding(b, strconv.Itoa, strconv.Atoi)
+	if conv.Get() != "1" {
+		t.Errorf("converted %q, want %q", conv.Get(), "1")
+	}
+	b.Set(2)
+	if conv.Get() != "2" {
+		t.Errorf("converted %q after Set, want %q", conv.Get(), "2")
+	}
+	conv.Set("3")
+	if b.Get() != 3 {
+		t.Errorf("value %d after converted Set, want 3", b.Get())
+	}
+	conv.Set("x")
+	if b.Get() != 3 {
+		t.Errorf("value %d after invalid Set, want 3", b.Get())
+	}
+
+	unsubscribe()
+	if len(b.subscribers) != 0 {
+		t.Errorf("%d subscribers after unsubscribe, want 0", len(b.subscribers))
+	}
+	b.Set(4)
+	if conv.Get() != "x" {
+		t.Errorf("converted %q after unsubscribe, want %q", conv.Get(), "x")
+	}
+	conv.Set("5")
+	if b.Get() != 4 {
+		t.Errorf("value %d after unsubscribe, want 4", b.Get())
+	}
+}
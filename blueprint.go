@@ -0,0 +1,370 @@
+package ui
+
+import "encoding/json"
+import "fmt"
+import "io"
+
+// Blueprint is a declarative description of a widget tree, usually loaded
+// from a JSON file with a BlueprintLoader. For example:
+//
+//	{
+//		"type": "box",
+//		"children": [
+//			{"type": "grid", "children": [
+//				{"type": "label", "text": "Name", "left": 0, "top": 0},
+//				{"type": "entry", "id": "name", "left": 1, "top": 0},
+//				{"type": "button", "text": "Save", "onClicked": "save",
+//				 "left": 1, "top": 1, "align": "right"}
+//			]}
+//		]
+//	}
+//
+// The type is one of box, tray, grid, slab, group, tab, label, text, button,
+// checkbox, radio, entry, password, search, note, journal, dropdown, slider
+// and picture.
+type Blueprint struct {
+	Type        string          `json:"type"`
+	ID          string          `json:"id,omitempty"`          // ID is the unique name of the widget in the Screen.
+	Text        string          `json:"text,omitempty"`        // Text is the text, title or caption of the widget, or the name of a tab page.
+	Icon        string          `json:"icon,omitempty"`        // Icon is the icon of a button, picture or tab page.
+	Placeholder string          `json:"placeholder,omitempty"` // Placeholder is the placeholder of an entry or note.
+	Items       []string        `json:"items,omitempty"`       // Items are the items of a dropdown or the toggles of a radio.
+	Selected    *int            `json:"selected,omitempty"`    // Selected is the selected item of a dropdown or radio.
+	Checked     bool            `json:"checked,omitempty"`
+	Disabled    bool            `json:"disabled,omitempty"`
+	Hidden      bool            `json:"hidden,omitempty"`
+	ReadOnly    bool            `json:"readOnly,omitempty"`
+	Padded      bool            `json:"padded,omitempty"`
+	Reversed    bool            `json:"reversed,omitempty"` // Reversed is for a journal.
+	Min         int             `json:"min,omitempty"`      // Min is the minimum of a slider.
+	Max         int             `json:"max,omitempty"`      // Max is the maximum of a slider.
+	Value       int             `json:"value,omitempty"`    // Value is the value of a slider.
+	Style       json.RawMessage `json:"style,omitempty"`    // Style overrides fields of the style of the widget.
+
+	// The placement in the parent Grid, or the position in a Slab.
+	Left    int        `json:"left,omitempty"`
+	Top     int        `json:"top,omitempty"`
+	Span    int        `json:"span,omitempty"`
+	RowSpan int        `json:"rowSpan,omitempty"`
+	Align   StyleAlign `json:"align,omitempty"`
+	VAlign  StyleAlign `json:"valign,omitempty"` // VAlign is the vertical alignment in a Grid.
+
+	// Handlers are bound by name with BlueprintLoader.Handle.
+	OnClicked  string `json:"onClicked,omitempty"`
+	OnChanged  string `json:"onChanged,omitempty"`
+	OnSelected string `json:"onSelected,omitempty"`
+
+	Children []*Blueprint `json:"children,omitempty"`
+}
+
+// Screen is a widget tree built from a Blueprint.
+type Screen struct {
+	root   Control
+	ids    map[string]Control
+	radios map[string]*Radio
+}
+
+// Root returns the root widget of the screen.
+func (s *Screen) Root() Control {
+	return s.root
+}
+
+// Widget returns the widget with the given id, or nil if there is none.
+func (s *Screen) Widget(id string) Control {
+	return s.ids[id]
+}
+
+// Radio returns the Radio of the radio with the given id, or nil if there
+// is none.
+func (s *Screen) Radio(id string) *Radio {
+	return s.radios[id]
+}
+
+// ScreenWidget returns the widget with the given id as a T, and whether it
+// was found with that type.
+func ScreenWidget[T Control](s *Screen, id string) (T, bool) {
+	t, ok := s.ids[id].(T)
+	return t, ok
+}
+
+// BlueprintLoader builds widget trees from blueprints. The callbacks of the
+// widgets are bound by name to the handlers that were registered with Handle.
+type BlueprintLoader struct {
+	handlers map[string]func(Control)
+}
+
+// NewBlueprintLoader returns a loader without handlers.
+func NewBlueprintLoader() *BlueprintLoader {
+	return &BlueprintLoader{handlers: map[string]func(Control){}}
+}
+
+// Handle registers the handler with the given name. The handler is called
+// with the widget that caused the event. For a radio, this is the selected
+// Toggle.
+func (l *BlueprintLoader) Handle(name string, handler func(Control)) {
+	l.handlers[name] = handler
+}
+
+// Load builds a screen from the JSON blueprint file with the given name in
+// the resources. Use MountResources to make the files available.
+func (l *BlueprintLoader) Load(name string) (*Screen, error) {
+	rd, err := resources.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer rd.Close()
+	screen, err := l.Read(rd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return screen, nil
+}
+
+// Read builds a screen from a JSON blueprint read from rd.
+func (l *BlueprintLoader) Read(rd io.Reader) (*Screen, error) {
+	bp := &Blueprint{}
+	if err := json.NewDecoder(rd).Decode(bp); err != nil {
+		return nil, err
+	}
+	return l.Build(bp)
+}
+
+// Build builds a screen from the blueprint.
+func (l *BlueprintLoader) Build(bp *Blueprint) (*Screen, error) {
+	s := &Screen{ids: map[string]Control{}, radios: map[string]*Radio{}}
+	root, err := l.build(s, bp)
+	if err != nil {
+		return nil, err
+	}
+	s.root = root
+	return s, nil
+}
+
+func (l *BlueprintLoader) handler(name string) (func(Control), error) {
+	if name == "" {
+		return nil, nil
+	}
+	h, ok := l.handlers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown handler %q", name)
+	}
+	return h, nil
+}
+
+// blueprintError adds the type and id of the blueprint to err.
+func blueprintError(bp *Blueprint, err error) error {
+	if bp.ID != "" {
+		return fmt.Errorf("%s %q: %w", bp.Type, bp.ID, err)
+	}
+	return fmt.Errorf("%s: %w", bp.Type, err)
+}
+
+func (l *BlueprintLoader) build(s *Screen, bp *Blueprint) (Control, error) {
+	c, err := l.buildWidget(s, bp)
+	if err != nil {
+		return nil, blueprintError(bp, err)
+	}
+
+	if bp.ID != "" {
+		if _, ok := s.ids[bp.ID]; ok {
+			return nil, blueprintError(bp, fmt.Errorf("duplicate id"))
+		}
+		s.ids[bp.ID] = c
+	}
+
+	if len(bp.Style) > 0 {
+		style := c.Style()
+		if err := json.Unmarshal(bp.Style, &style); err != nil {
+			return nil, blueprintError(bp, fmt.Errorf("style: %w", err))
+		}
+		c.SetStyle(&style)
+	}
+	if bp.Hidden {
+		c.Hide()
+	}
+	if bp.Disabled {
+		if d, ok := c.(interface{ Disable() }); ok {
+			d.Disable()
+		}
+	}
+	return c, nil
+}
+
+// buildChildren builds the children of the blueprint and calls add for each.
+func (l *BlueprintLoader) buildChildren(s *Screen, bp *Blueprint, add func(child *Blueprint, c Control)) error {
+	for _, child := range bp.Children {
+		c, err := l.build(s, child)
+		if err != nil {
+			return err
+		}
+		add(child, c)
+	}
+	return nil
+}
+
+// buildChild builds the only child of the blueprint, if any.
+func (l *BlueprintLoader) buildChild(s *Screen, bp *Blueprint) (Control, error) {
+	switch len(bp.Children) {
+	case 0:
+		return nil, nil
+	case 1:
+		return l.build(s, bp.Children[0])
+	}
+	return nil, fmt.Errorf("can only have one child")
+}
+
+func (l *BlueprintLoader) buildWidget(s *Screen, bp *Blueprint) (Control, error) {
+	onClicked, err := l.handler(bp.OnClicked)
+	if err != nil {
+		return nil, err
+	}
+	onChanged, err := l.handler(bp.OnChanged)
+	if err != nil {
+		return nil, err
+	}
+	onSelected, err := l.handler(bp.OnSelected)
+	if err != nil {
+		return nil, err
+	}
+
+	switch bp.Type {
+	case "box":
+		box := NewVerticalBox()
+		err := l.buildChildren(s, bp, func(_ *Blueprint, c Control) { box.Append(c) })
+		return box, err
+	case "tray":
+		tray := NewTray()
+		tray.SetPadded(bp.Padded)
+		err := l.buildChildren(s, bp, func(_ *Blueprint, c Control) { tray.Append(c) })
+		return tray, err
+	case "grid":
+		grid := NewGrid()
+		err := l.buildChildren(s, bp, func(child *Blueprint, c Control) {
+			mesh := NewMesh(c, max(0, child.Left), max(0, child.Top), max(1, child.Span), child.Align)
+			grid.AppendMesh(mesh.SetRowSpan(max(1, child.RowSpan)).SetVerticalAlign(child.VAlign))
+		})
+		return grid, err
+	case "slab":
+		slab := NewSlab()
+		slab.SetPadded(bp.Padded)
+		err := l.buildChildren(s, bp, func(child *Blueprint, c Control) { slab.Append(c, child.Left, child.Top) })
+		return slab, err
+	case "group":
+		group := NewGroup(bp.Text)
+		child, err := l.buildChild(s, bp)
+		if child != nil {
+			group.SetChild(child)
+		}
+		return group, err
+	case "tab":
+		tab := NewTab()
+		err := l.buildChildren(s, bp, func(child *Blueprint, c Control) { tab.AppendWithIcon(child.Icon, child.Text, c) })
+		if err == nil && tab.NumPages() > 0 {
+			tab.Select(0)
+		}
+		return tab, err
+	case "label":
+		return NewLabel(bp.Text), nil
+	case "text":
+		if bp.Icon != "" {
+			return NewIconTextWidget(bp.Icon, bp.Text), nil
+		}
+		return NewTextWidget(bp.Text), nil
+	case "button":
+		var button *Button
+		if bp.Icon != "" {
+			button = NewButtonWithIcon(bp.Text, bp.Icon)
+		} else {
+			button = NewButton(bp.Text)
+		}
+		if onClicked != nil {
+			button.OnClicked(func(b *Button) { onClicked(b) })
+		}
+		return button, nil
+	case "checkbox":
+		checkbox := NewCheckbox(bp.Text)
+		checkbox.SetChecked(bp.Checked)
+		if onClicked != nil {
+			checkbox.OnClicked(func(c *Checkbox) { onClicked(c) })
+		}
+		return checkbox, nil
+	case "radio":
+		radio := NewRadio()
+		box := NewVerticalBox()
+		for _, item := range bp.Items {
+			box.Append(radio.Append(item))
+		}
+		if bp.Selected != nil {
+			radio.SetSelected(*bp.Selected)
+		}
+		if onSelected != nil {
+			radio.OnSelected(func(r *Radio) {
+				if r.selected >= 0 && r.selected < len(r.toggles) {
+					onSelected(r.toggles[r.selected])
+				}
+			})
+		}
+		if bp.ID != "" {
+			s.radios[bp.ID] = radio
+		}
+		return box, nil
+	case "entry", "password", "search":
+		var entry *Entry
+		switch bp.Type {
+		case "password":
+			entry = NewPasswordEntry()
+		case "search":
+			entry = NewSearchEntry()
+		default:
+			entry = NewEntry()
+		}
+		entry.SetText(bp.Text)
+		entry.SetReadOnly(bp.ReadOnly)
+		if bp.Placeholder != "" {
+			entry.SetPlaceholder(bp.Placeholder)
+		}
+		if onChanged != nil {
+			entry.OnChanged(func(e *Entry) { onChanged(e) })
+		}
+		return entry, nil
+	case "note":
+		note := NewNote()
+		note.SetText(bp.Text)
+		if bp.Placeholder != "" {
+			note.SetPlaceholder(bp.Placeholder)
+		}
+		if onChanged != nil {
+			note.OnChanged(func(n *Note) { onChanged(n) })
+		}
+		return note, nil
+	case "journal":
+		journal := NewJournal(bp.Reversed)
+		journal.SetText(bp.Text)
+		return journal, nil
+	case "dropdown":
+		dropdown := NewDropdown()
+		for _, item := range bp.Items {
+			dropdown.Append(item)
+		}
+		if bp.Selected != nil {
+			dropdown.SetSelected(*bp.Selected)
+		}
+		if onSelected != nil {
+			dropdown.OnSelected(func(d *Dropdown) { onSelected(d) })
+		}
+		return dropdown, nil
+	case "slider":
+		if bp.Min >= bp.Max {
+			return nil, fmt.Errorf("min must be smaller than max")
+		}
+		slider := NewSlider(bp.Min, bp.Max)
+		slider.SetValue(bp.Value)
+		if onChanged != nil {
+			slider.OnChanged(func(s *Slider) { onChanged(s) })
+		}
+		return slider, nil
+	case "picture":
+		return NewPictureWithIcon(bp.Text, bp.Icon), nil
+	}
+	return nil, fmt.Errorf("unknown widget type")
+}
@@ -101,6 +101,7 @@ func (b *Box) LayoutWidget(width, height int) {
 
 	// Take on the widest width of the child plus margins as our width.
 	b.width = widest + margin*2
+	b.mirrorChildren(b.width)
 
 	// Finally clip to desired size.
 	b.ClipTo(width, height)
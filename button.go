@@ -1,12 +1,12 @@
 package ui
 
-import "github.com/hajimehoshi/ebiten/v2/text"
-
 type Button struct {
 	TextWidget
 	onClicked func(*Button)
 	pressed   bool
 	icon      string
+	hover     float64    // hover goes from 0 to 1 when the mouse pointer is over the button.
+	hovering  *Animation // hovering animates hover.
 }
 
 func (b *Button) OnClicked(f func(*Button)) {
@@ -17,6 +17,7 @@ func NewButton(text string) *Button {
 	b := &Button{}
 	b.SetText(text)
 	b.customStyle = theme.Button
+	b.focusable = true
 	return b
 }
 
@@ -42,6 +43,19 @@ func (b *Button) LayoutWidget(width, height int) {
 	b.ClipTo(width, height)
 }
 
+// HoverWidget blends the fill color of the button to the one of the hover
+// style of the theme while the mouse pointer is over it.
+func (b *Button) HoverWidget(hovered bool) {
+	to := 0.0
+	if hovered {
+		to = 1.0
+	}
+	stopAnimation(b.hovering)
+	b.hovering = AnimateFloat(b.hover, to, animationShort, EaseOutQuad, func(hover float64) {
+		b.hover = hover
+	}).Invalidates(b).Start()
+}
+
 func (b Button) DrawWidget(dst *Graphic) {
 	dx, dy := b.WidgetAbsolute()
 
@@ -56,6 +70,8 @@ func (b Button) DrawWidget(dst *Graphic) {
 		active := *theme.Active
 		// only use the color, not the sprite
 		style.Fill.Color = active.Fill.Color
+	} else if b.hover > 0 {
+		style.Fill.Color = lerpColor(style.Fill.Color, theme.Hover.Fill.Color, b.hover)
 	}
 
 	FillFrameStyle(dst, dx, dy, b.width, b.height, style)
@@ -65,7 +81,7 @@ func (b Button) DrawWidget(dst *Graphic) {
 		dx += margin
 		dy += margin
 		dy += textFace.Metrics().Ascent.Round()
-		text.Draw(dst, b.text, textFace, dx, dy, textColor)
+		TextDraw(dst, b.text, textFace, dx, dy, textColor)
 	}
 
 	if b.icon != "" {
@@ -0,0 +1,162 @@
+package ui
+
+import "image"
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// The drawing of a widget can be cached in an offscreen graphic, so it is
+// only drawn again when it was invalidated. A cache is invalidated when the
+// widgets in it are laid out or handle an event, and by Invalidate. The
+// setters of the widgets that change how they are drawn, such as SetText,
+// SetChecked, SetValue, SetStyle, Show, Hide and the focus, invalidate the
+// caches that contain the widget, and so do the animations of a widget.
+
+// Cacher is implemented by widgets that cache their drawing.
+type Cacher interface {
+	// InvalidateCache makes the widget draw again on the next frame.
+	InvalidateCache()
+}
+
+// Invalidate invalidates the caches of c and of the widgets that contain it,
+// so c is drawn again. Call it after changing a widget in a cache in a way
+// that the cache can't notice.
+func Invalidate(c Control) {
+	for ; c != nil; c = c.Parent() {
+		if cacher, ok := c.(Cacher); ok {
+			cacher.InvalidateCache()
+		}
+	}
+	RequestRedraw()
+}
+
+// widgetCache is the offscreen graphic of a cache.
+type widgetCache struct {
+	graphic *Graphic
+	dirty   bool
+}
+
+func (wc *widgetCache) invalidate() {
+	wc.dirty = true
+}
+
+// draw draws the rectangle from the cache on dst. If the cache is invalid,
+// render first draws the rectangle again on the cache, with the same
+// coordinates as on dst. With the software renderer the cache is not used.
+func (wc *widgetCache) draw(dst *Graphic, x, y, w, h int, render func(dst *Graphic)) {
+	if w < 1 || h < 1 {
+		return
+	}
+	if softRendererOf(dst) != nil {
+		render(dst)
+		return
+	}
+	bounds := image.Rect(x, y, x+w, y+h)
+	if wc.graphic == nil || wc.graphic.Bounds() != bounds {
+		if wc.graphic != nil {
+			wc.graphic.Deallocate()
+		}
+		// The bounds of the graphic start at x, y, so the widgets can draw
+		// on it with their absolute coordinates.
+		wc.graphic = ebiten.NewImageWithOptions(bounds, nil)
+		wc.dirty = true
+	}
+	if wc.dirty {
+		wc.graphic.Clear()
+		render(wc.graphic)
+		wc.dirty = false
+	}
+	GraphicRenderer(dst).DrawGraphic(wc.graphic, x, y, w, h, colorWhite)
+}
+
+// release frees the offscreen graphic.
+func (wc *widgetCache) release() {
+	if wc.graphic != nil {
+		wc.graphic.Deallocate()
+		wc.graphic = nil
+	}
+}
+
+// Cache is a widget that caches the drawing of its child widget, which is
+// then only drawn again when it was invalidated. This saves a lot of
+// processing for parts of the screen that don't change often. Only the
+// child is cached, so widgets in it that draw outside of its bounds, such
+// as an open Dropdown, are clipped.
+type Cache struct {
+	BasicWidget
+	child Control
+	cache widgetCache
+}
+
+// NewCache returns a cache for child.
+func NewCache(child Control) *Cache {
+	c := &Cache{}
+	c.SetStyle(&theme.Style)
+	c.SetChild(child)
+	return c
+}
+
+func (c *Cache) SetChild(child Control) {
+	if c.child != nil {
+		c.child.SetParent(nil)
+	}
+	c.child = child
+	if child != nil {
+		child.SetParent(c)
+	}
+	c.cache.invalidate()
+	NeedLayout(c)
+}
+
+// Child returns the cached widget.
+func (c *Cache) Child() Control {
+	return c.child
+}
+
+// InvalidateCache makes the child draw again on the next frame.
+func (c *Cache) InvalidateCache() {
+	c.cache.invalidate()
+}
+
+func (c *Cache) LayoutWidget(width, height int) {
+	c.width, c.height = 0, 0
+	if c.child != nil {
+		c.child.LayoutWidget(width, height)
+		c.child.MoveWidget(0, 0)
+		c.width, c.height = c.child.WidgetSize()
+	}
+	c.cache.invalidate()
+	c.ClipTo(width, height)
+}
+
+func (c *Cache) DrawWidget(dst *Graphic) {
+	if c.child == nil || c.child.Hidden() {
+		return
+	}
+	dx, dy := c.WidgetAbsolute()
+	c.cache.draw(dst, dx, dy, c.width, c.height, c.child.DrawWidget)
+}
+
+// HandleWidget passes the event to the child. As the event might change
+// the child, the cache is invalidated, except for update events, which are
+// sent every tick. Widgets that change on an update call Invalidate.
+func (c *Cache) HandleWidget(ev Event) {
+	if c.child != nil {
+		c.child.HandleWidget(ev)
+	}
+	if _, ok := ev.(*UpdateEvent); !ok {
+		c.cache.invalidate()
+	}
+}
+
+func (c *Cache) Destroy() {
+	c.Hide()
+	c.cache.release()
+	if c.child != nil {
+		c.child.SetParent(nil)
+		c.child.Destroy()
+	}
+}
+
+var _ Control = &Cache{}
+var _ Cacher = &Cache{}
+var _ HasChild = &Cache{}
@@ -0,0 +1,95 @@
+package ui
+
+import "testing"
+
+func TestInvalidate(t *testing.T) {
+	label, other := NewLabel("label"), NewLabel("other")
+	checkbox := NewCheckbox("checkbox")
+	inner := NewVerticalBox()
+	inner.Append(label)
+	inner.Append(checkbox)
+	cache := NewCache(inner)
+	box := NewVerticalBox()
+	box.Append(cache)
+	box.Append(other)
+
+	tests := []struct {
+		name   string
+		change func()
+		dirty  bool
+	}{
+		{"other text", func() { other.SetText("changed") }, false},
+		{"other style", func() { other.SetStyle(theme.Label) }, false},
+		{"other hidden", other.Hide, false},
+		{"text", func() { checkbox.SetText("changed") }, true},
+		{"style", func() { label.SetStyle(theme.Label) }, true},
+		{"hidden", label.Hide, true},
+		{"shown", label.Show, true},
+		{"disabled", label.Disable, true},
+		{"checked", func() { checkbox.SetChecked(true) }, true},
+		{"focus", func() { inner.SetFocus(checkbox) }, true},
+	}
+	for _, tt := range tests {
+		cache.cache.dirty = false
+		tt.change()
+		if cache.cache.dirty != tt.dirty {
+			t.Errorf("%s: dirty %v, want %v", tt.name, cache.cache.dirty, tt.dirty)
+		}
+	}
+}
+
+func TestAnimationInvalidates(t *testing.T) {
+	SetReducedMotion(false)
+	defer SetReducedMotion(true)
+
+	button := NewButton("button")
+	cache, other := NewCache(button), NewCache(NewLabel("other"))
+	button.HoverWidget(true)
+	for i := 0; button.hovering.Running() && i < 100; i++ {
+		cache.cache.dirty, other.cache.dirty = false, false
+		runAnimations(animationShort / 4)
+		if !cache.cache.dirty {
+			t.Fatalf("step %d: the cache of the button is not invalidated", i)
+		}
+		if other.cache.dirty {
+			t.Fatalf("step %d: another cache is invalidated", i)
+		}
+	}
+	if button.hovering.Running() {
+		t.Errorf("the animation does not end")
+	}
+}
+
+// tableTestModel is a table model with one text column.
+type tableTestModel []Row
+
+func (m tableTestModel) NumRows() int                 { return len(m) }
+func (m tableTestModel) FetchRow(index int) Row       { return m[index] }
+func (m tableTestModel) UpdateRow(index int, row Row) { m[index] = row }
+
+func TestTableInvalidate(t *testing.T) {
+	table := NewTable(tableTestModel{NewRow(NewValue("cell"))})
+	table.AppendColumn(NewTextColumn("Name", 0))
+	table.LayoutWidget(200, 100)
+	me := MouseEvent{X: 5, Y: 5}
+
+	tests := []struct {
+		name  string
+		event Event
+		dirty bool
+	}{
+		{"update", &UpdateEvent{}, false},
+		{"mouse move", &MouseMoveEvent{MouseEvent: me}, false},
+		{"click", &MouseClickEvent{MouseEvent: me}, true},
+		{"release", &MouseReleaseEvent{MouseEvent: me}, true},
+		{"wheel", &WheelEvent{MouseEvent: me, WheelY: 1}, true},
+		{"key", &KeyPressEvent{KeyEvent: KeyEvent{Key: KeyArrowDown}}, true},
+	}
+	for _, tt := range tests {
+		table.cache.dirty = false
+		table.HandleWidget(tt.event)
+		if table.cache.dirty != tt.dirty {
+			t.Errorf("%s: dirty %v, want %v", tt.name, table.cache.dirty, tt.dirty)
+		}
+	}
+}
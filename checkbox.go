@@ -4,6 +4,8 @@ type Checkbox struct {
 	TextWidget
 	onClicked func(*Checkbox)
 	checked   bool
+	binding   widgetBinding
+	Validation
 }
 
 func (b *Checkbox) Checked() bool {
@@ -12,6 +14,7 @@ func (b *Checkbox) Checked() bool {
 
 func (b *Checkbox) SetChecked(checked bool) {
 	b.checked = checked
+	Invalidate(b)
 }
 
 func (b *Checkbox) OnClicked(f func(*Checkbox)) {
@@ -23,6 +26,7 @@ func NewCheckbox(text string) *Checkbox {
 	b.SetText(text)
 	b.SetChecked(false)
 	b.customStyle = theme.Checkbox
+	b.focusable = true
 	return b
 }
 
@@ -45,6 +49,7 @@ func (b *Checkbox) LayoutWidget(width, height int) {
 
 func (b Checkbox) DrawWidget(dst *Graphic) {
 	dx, dy := b.WidgetAbsolute()
+	x, y := dx, dy
 
 	style := b.Style()
 
@@ -62,10 +67,12 @@ func (b Checkbox) DrawWidget(dst *Graphic) {
 	dx += checkboxWidth + margin
 	dy += checkboxHeight
 	TextDrawStyle(dst, b.text, dx, dy, style)
+	b.drawError(dst, x, y, b.width, b.height)
 	b.DrawDebug(dst, "CHE")
 }
 
 func (b *Checkbox) HandleWidget(ev Event) {
+	before := b.validationValue()
 	if _, ok := ev.(*MouseClickEvent); ok {
 		dprintln("Checkbox.HandleWidget: ")
 		b.checked = !b.checked
@@ -83,4 +90,6 @@ func (b *Checkbox) HandleWidget(ev Event) {
 			b.onClicked(b)
 		}
 	}
+	b.binding.changed()
+	b.Validation.changed(before, b.validationValue())
 }
@@ -38,6 +38,7 @@ type BasicContainer struct {
 	tab             int
 	mouseFocusIndex int
 	mouseFocus      Control
+	mirrored        bool // mirrored is set if the children were laid out right to left.
 }
 
 const containerLayerOffset = 100000
@@ -53,7 +54,7 @@ func (c BasicContainer) WidgetLayer() int {
 func (c BasicContainer) DrawWidget(g *Graphic) {
 	dx, dy := c.WidgetAbsolute() // NOTE static inheritance !
 
-	if c.tab > 0 && c.tab < len(c.controls) {
+	if c.tab > 0 && c.tab <= len(c.controls) {
 		tabChild := c.controls[c.tab-1]
 		wx, wy := tabChild.WidgetAt()
 		ww, wh := tabChild.WidgetSize()
@@ -108,17 +109,6 @@ func (c *BasicContainer) HandleWidget(ev Event) {
 		if kr.Key == ebiten.KeyF2 {
 			debugDisplay = !debugDisplay
 		}
-		if kr.Key == ebiten.KeyTab {
-			if len(c.controls) > 0 && c.tab > 0 && c.Focus() != nil { // XXX static inheritance !
-				c.tab++
-				if c.tab >= len(c.controls) {
-					c.tab = 1
-				}
-				control := c.controls[c.tab]
-				SetNewFocus(kr.Event(), c, control, c.controls...) // XXX static inheritance !
-				return
-			}
-		}
 	}
 
 	HandleContainerIfNeeded(ev, c)
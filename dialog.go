@@ -17,6 +17,7 @@ type Dialog struct {
 	tray     *Tray   // Tray with buttons to display on the bottom.
 	onResult func(*Dialog)
 	result   DialogResult
+	form     *Form // Form validates the child before the dialog is closed with OK.
 }
 
 // DialogResult is the result of showing an Dialog or dialog, decided by the
@@ -60,6 +61,7 @@ func newDialog(title string, child Control) *Dialog {
 	a.box = NewBox()
 	a.tray = NewTray()
 	a.child = child
+	a.form = NewForm(child)
 	style := theme.Pane
 
 	a.Pane = NewPane(title, style.Size.Width.Int(), style.Size.Height.Int(), false)
@@ -77,9 +79,26 @@ func newDialog(title string, child Control) *Dialog {
 	return a
 }
 
+// Form returns the form of the contents of the dialog.
+func (a *Dialog) Form() *Form {
+	return a.form
+}
+
+// accept returns whether the dialog may send the result. The result OK is
+// refused while the form of the dialog is invalid.
+func (a *Dialog) accept(result DialogResult) bool {
+	return result != DialogResultOK || a.form.Validate() == nil
+}
+
+// AddButton adds a button that sends the result and closes the dialog.
+// A button with the result DialogResultOK does nothing while the contents
+// of the dialog are invalid, and focuses the first invalid field instead.
 func (a *Dialog) AddButton(title string, result DialogResult) *Dialog {
 	button := NewButton(title)
 	button.OnClicked(func(b *Button) {
+		if !a.accept(result) {
+			return
+		}
 		a.SendResult(result)
 		a.closePane()
 	})
@@ -90,6 +109,9 @@ func (a *Dialog) AddButton(title string, result DialogResult) *Dialog {
 func (a *Dialog) AddButtonKeepOpen(title string, result DialogResult) *Dialog {
 	button := NewButton(title)
 	button.OnClicked(func(b *Button) {
+		if !a.accept(result) {
+			return
+		}
 		a.SendResult(result)
 	})
 	a.tray.Append(button)
@@ -0,0 +1,132 @@
+package ui
+
+import "strings"
+
+// LayoutDirection is the direction in which widgets and text flow
+// horizontally.
+type LayoutDirection int
+
+const (
+	// LayoutInherit uses the direction of the parent, or left to right if
+	// no parent has a direction.
+	LayoutInherit LayoutDirection = iota
+	// LayoutLeftToRight is the direction of languages such as English.
+	LayoutLeftToRight
+	// LayoutRightToLeft is the direction of languages such as Arabic and
+	// Hebrew. Containers mirror the positions of their children, the start
+	// is on the right and the end on the left.
+	LayoutRightToLeft
+)
+
+func (d LayoutDirection) String() string {
+	switch d {
+	case LayoutLeftToRight:
+		return "ltr"
+	case LayoutRightToLeft:
+		return "rtl"
+	default:
+		return "inherit"
+	}
+}
+
+// LayoutDirection returns the direction that was set on the widget, which is
+// LayoutInherit if none was set.
+func (w BasicWidget) LayoutDirection() LayoutDirection {
+	return w.direction
+}
+
+// SetLayoutDirection sets the direction of the widget and the widgets in it
+// that inherit it.
+func (w *BasicWidget) SetLayoutDirection(direction LayoutDirection) {
+	w.direction = direction
+	Invalidate(w)
+	NeedLayout(w)
+}
+
+// SetLayoutDirection sets the direction of all widgets in the window that
+// inherit it.
+func (w *Window) SetLayoutDirection(direction LayoutDirection) {
+	w.BasicWidget.SetLayoutDirection(direction)
+	w.Relayout()
+}
+
+// RightToLeft returns whether the widget is laid out from right to left,
+// either because its direction is set, or inherited from a parent.
+func (w BasicWidget) RightToLeft() bool {
+	if w.direction != LayoutInherit {
+		return w.direction == LayoutRightToLeft
+	}
+	return LayoutDirectionOf(w.parent) == LayoutRightToLeft
+}
+
+// layoutDirector is implemented by the widgets that embed BasicWidget.
+type layoutDirector interface {
+	LayoutDirection() LayoutDirection
+}
+
+// LayoutDirectionOf returns the direction of c, which is the first direction
+// set on c or one of its parents. It is LayoutLeftToRight if none is set.
+func LayoutDirectionOf(c Control) LayoutDirection {
+	for ; c != nil; c = c.Parent() {
+		if director, ok := c.(layoutDirector); ok {
+			if direction := director.LayoutDirection(); direction != LayoutInherit {
+				return direction
+			}
+		}
+	}
+	return LayoutLeftToRight
+}
+
+// Directed returns the alignment for a layout direction. From right to left,
+// the left and right alignments are swapped, and the default alignment is
+// at the start, so on the right.
+func (a StyleAlign) Directed(rightToLeft bool) StyleAlign {
+	if !rightToLeft {
+		return a
+	}
+	switch a {
+	case StyleAlignDefault, StyleAlignLeft:
+		return StyleAlignRight
+	case StyleAlignRight:
+		return StyleAlignLeft
+	}
+	return a
+}
+
+// mirrorChildren mirrors the horizontal positions of the children within
+// width if the container is laid out from right to left. Containers call it
+// at the end of LayoutWidget, after placing the children from left to right.
+func (b *BasicContainer) mirrorChildren(width int) {
+	b.mirrored = b.RightToLeft()
+	if !b.mirrored {
+		return
+	}
+	for _, child := range b.controls {
+		x, y := child.WidgetAt()
+		childWidth, _ := child.WidgetSize()
+		child.MoveWidget(width-x-childWidth, y)
+	}
+}
+
+// resizeWidget keeps the children of a mirrored container on the right side
+// when it is stretched.
+func (b *BasicContainer) resizeWidget(width, height int) {
+	if shift := width - b.width; b.mirrored && shift != 0 {
+		for _, child := range b.controls {
+			x, y := child.WidgetAt()
+			child.MoveWidget(x+shift, y)
+		}
+	}
+	b.BasicWidget.resizeWidget(width, height)
+}
+
+// TextDrawAligned draws str with y as the base line of the first line, and
+// aligns each line within width from x.
+func TextDrawAligned(dst *Graphic, str string, face Face, x, y, width int, align StyleAlign, col Color) {
+	lineHeight := face.Metrics().Height.Round()
+	for _, line := range strings.Split(str, "\n") {
+		lineWidth, _ := oneLineTextSize(face, line)
+		TextDraw(dst, line, face, align.Position(x, lineWidth, width), y, col)
+		y += lineHeight
+	}
+}
@@ -0,0 +1,156 @@
+package ui
+
+// DockSide is the side of a Dock a widget is docked to.
+type DockSide int
+
+const (
+	DockCenter DockSide = iota // DockCenter fills the space that the edges leave.
+	DockTop                    // DockTop docks the widget to the top edge.
+	DockBottom                 // DockBottom docks the widget to the bottom edge.
+	DockLeft                   // DockLeft docks the widget to the left edge.
+	DockRight                  // DockRight docks the widget to the right edge.
+)
+
+// A Dock is a container where the widgets are docked to an edge or fill the
+// center, like a border layout. The widgets are docked in the order they
+// were appended, each one taking its side from the space that the earlier
+// ones left. Widgets on the top and bottom get their preferred height and
+// are stretched to the width that is left, widgets on the left and right
+// get their preferred width and are stretched to the height that is left.
+// The widgets in the center fill the space that remains. For example, a
+// tool bar at the top, a status bar at the bottom, a navigation on the left
+// and a table in the center. The dock takes all the space that is
+// available. From right to left, the left and right sides are swapped.
+type Dock struct {
+	BasicContainer
+	sides map[Control]DockSide
+}
+
+// NewDock returns an empty dock.
+func NewDock() *Dock {
+	d := &Dock{sides: map[Control]DockSide{}}
+	d.controls = []Control{}
+	return d
+}
+
+// Append docks c to the side.
+func (d *Dock) Append(c Control, side DockSide) {
+	d.sides[c] = side
+	d.BasicContainer.AppendWithParent(c, d)
+}
+
+// Side returns the side c is docked to.
+func (d Dock) Side(c Control) DockSide {
+	return d.sides[c]
+}
+
+// SetSide docks c to another side.
+func (d *Dock) SetSide(c Control, side DockSide) {
+	d.sides[c] = side
+	NeedLayout(d)
+}
+
+func (d *Dock) Delete(index int) {
+	if index < 0 || index >= len(d.controls) {
+		return
+	}
+	child := d.controls[index]
+	delete(d.sides, child)
+	d.BasicContainer.Delete(index)
+	child.SetParent(nil)
+}
+
+func (d *Dock) Destroy() {
+	for _, child := range d.controls {
+		child.SetParent(nil)
+		child.Destroy()
+	}
+}
+
+// measure lays out the widgets with their preferred size, and returns the
+// size the dock needs for them.
+func (d *Dock) measure(width, height int) (needWidth, needHeight int) {
+	usedWidth, usedHeight := 0, 0
+	for _, child := range d.controls {
+		if child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(max(1, width-usedWidth), max(1, height-usedHeight))
+		cw, ch := child.WidgetSize()
+		switch d.sides[child] {
+		case DockTop, DockBottom:
+			needWidth = max(needWidth, usedWidth+cw)
+			usedHeight += ch
+		case DockLeft, DockRight:
+			needHeight = max(needHeight, usedHeight+ch)
+			usedWidth += cw
+		default:
+			needWidth = max(needWidth, usedWidth+cw)
+			needHeight = max(needHeight, usedHeight+ch)
+		}
+	}
+	return max(needWidth, usedWidth), max(needHeight, usedHeight)
+}
+
+func (d *Dock) LayoutWidget(width, height int) {
+	margin := d.Style().Margin.Int()
+	availableWidth := width - margin*2
+	availableHeight := height - margin*2
+
+	needWidth, needHeight := d.measure(availableWidth, availableHeight)
+	if layoutBounded(availableWidth) {
+		needWidth = availableWidth
+	}
+	if layoutBounded(availableHeight) {
+		needHeight = availableHeight
+	}
+
+	// Dock the widgets, each one takes its side from the space that is left.
+	x, y, w, h := margin, margin, needWidth, needHeight
+	for _, child := range d.controls {
+		if child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(max(1, w), max(1, h))
+		cw, ch := child.WidgetSize()
+		cw, ch = min(cw, max(0, w)), min(ch, max(0, h))
+		switch d.sides[child] {
+		case DockTop:
+			stretchWidget(child, w, 0)
+			child.MoveWidget(x, y)
+			y += ch
+			h -= ch
+		case DockBottom:
+			stretchWidget(child, w, 0)
+			child.MoveWidget(x, y+h-ch)
+			h -= ch
+		case DockLeft:
+			stretchWidget(child, 0, h)
+			child.MoveWidget(x, y)
+			x += cw
+			w -= cw
+		case DockRight:
+			stretchWidget(child, 0, h)
+			child.MoveWidget(x+w-cw, y)
+			w -= cw
+		default:
+			stretchWidget(child, w, h)
+			child.MoveWidget(x, y)
+		}
+	}
+
+	d.width = needWidth + margin*2
+	d.height = needHeight + margin*2
+	d.mirrorChildren(d.width)
+	d.ClipTo(width, height)
+	d.BasicContainer.UpdateOrdered()
+}
+
+func (d Dock) DrawWidget(g *Graphic) {
+	dx, dy := d.WidgetAbsolute()
+	FillFrameStyle(g, dx, dy, d.width, d.height, d.Style())
+	d.BasicContainer.DrawWidget(g)
+	d.DrawDebug(g, "DOC")
+}
+
+var _ Control = &Dock{}
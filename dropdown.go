@@ -6,6 +6,8 @@ type Dropdown struct {
 	committed  int
 	active     bool
 	overlay    dropdownOverlay
+	binding    widgetBinding
+	Validation
 }
 
 // widget for the overlay of the dropdown
@@ -90,6 +92,7 @@ const dropdownHeight = 16
 func NewDropdown() *Dropdown {
 	d := &Dropdown{}
 	d.customStyle = theme.Dropdown
+	d.focusable = true
 	d.overlay.customStyle = theme.Dropdown
 	d.overlay.SetParent(d)
 	d.overlay.dropdown = d
@@ -128,6 +131,7 @@ func (c *Dropdown) Selected() int {
 func (c *Dropdown) SetSelected(index int) {
 	c.overlay.selected = index
 	c.committed = c.overlay.selected
+	Invalidate(c)
 }
 
 func (c *Dropdown) textWidget(index int) *TextWidget {
@@ -155,6 +159,7 @@ func (c *Dropdown) SetText(text string) {
 			}
 		}
 	}
+	Invalidate(c)
 }
 
 func (d *Dropdown) LayoutWidget(width, height int) {
@@ -205,6 +210,7 @@ func (d *Dropdown) DrawWidget(dst *Graphic) {
 	}
 
 	TextDrawOffsetStyle(sub, text, dx, dy, d.Style())
+	d.drawError(dst, dx, dy, d.width, d.height)
 	if d.active {
 		d.overlay.DrawWidget(dst)
 	}
@@ -269,6 +275,7 @@ func (d *Dropdown) HandleMouseClick(mc *MouseClickEvent) {
 }
 
 func (e *Dropdown) HandleWidget(ev Event) {
+	before := e.Text()
 	// If we get an away event, deactivate
 	if _, ok := ev.(*AwayEvent); ok {
 		dprintln("Dropdown.HandleWidget deactivate")
@@ -287,4 +294,6 @@ func (e *Dropdown) HandleWidget(ev Event) {
 			e.HandleKeyRelease(ke)
 		}
 	}
+	e.binding.changed()
+	e.Validation.changed(before, e.Text())
 }
@@ -24,6 +24,8 @@ type Entry struct {
 	closeInputState func()
 	lastInputState  TextInputState
 	active          bool
+	binding         widgetBinding
+	Validation
 }
 
 type PasswordEntry struct {
@@ -44,6 +46,7 @@ const entryMinWidth = 100.0
 func newEntry(kind entryKind) *Entry {
 	e := &Entry{}
 	e.entryKind = kind
+	e.focusable = true
 
 	// Set placeholder
 	if kind == entryKindNormal {
@@ -101,7 +104,13 @@ func (e Entry) DrawWidget(dst *Graphic) {
 	FillFrameStyle(dst, dx, dy, e.width, e.height, e.Style())
 	sub := GraphicClipStyle(dst, dx, dy, e.width, e.height, e.Style())
 
-	TextDrawOffsetStyle(sub, e.text, dx, dy, e.Style())
+	// From right to left, the text is aligned to the right.
+	textX := dx
+	textW, _ := oneLineTextSize(textFace, e.text)
+	if e.RightToLeft() {
+		textX = dx + e.width - margin*2 - textW
+	}
+	TextDrawOffsetStyle(sub, e.text, textX, dy, e.Style())
 	// draw cursor if focused
 	if e.active {
 		cut := string(e.input[:e.cursor])
@@ -109,7 +118,12 @@ func (e Entry) DrawWidget(dst *Graphic) {
 			cut = strings.Repeat("*", e.cursor) // XXX this allocates too much I think.
 		}
 		curW, curH := oneLineTextSize(textFace, cut)
-		curX := dx + curW + margin
+		// In right to left text the cursor moves from the right. For
+		// mixed text this is only an approximation.
+		if e.textRightToLeft() {
+			curW = textW - curW
+		}
+		curX := textX + curW + margin
 		curY := dy + margin
 
 		StrokeLine(sub, curX+cursorThick, curY, 0, curH, cursorThick, lineColorCursor)
@@ -122,6 +136,7 @@ func (e Entry) DrawWidget(dst *Graphic) {
 			StrokeLine(dst, curX, curY+ch*3/4, cw, 0, cursorThick, lineColorCursor)
 		}
 	}
+	e.drawError(dst, dx, dy, e.width, e.height)
 	e.DrawDebug(dst, "ENT")
 }
 
@@ -151,6 +166,7 @@ func (c *Entry) ReadOnly() bool {
 
 func (c *Entry) SetReadOnly(readonly bool) {
 	c.readonly = readonly
+	Invalidate(c)
 }
 
 func (c *Entry) Placeholder() string {
@@ -159,6 +175,7 @@ func (c *Entry) Placeholder() string {
 
 func (c *Entry) SetPlaceholder(text string) {
 	c.placeholder = text
+	Invalidate(c)
 }
 
 func (e *Entry) HandleTextInputState(state TextInputState) {
@@ -170,6 +187,7 @@ func (e *Entry) HandleTextInputState(state TextInputState) {
 	} else {
 		e.lastInputState = state
 	}
+	Invalidate(e)
 }
 
 func (e *Entry) startTextInput() {
@@ -231,6 +249,7 @@ func (e *Entry) insertRunes(runes []rune) {
 }
 
 func (e *Entry) HandleWidget(ev Event) {
+	before := e.Text()
 	// If we get an away event, deactivate
 	if _, ok := ev.(*AwayEvent); ok {
 		dprintln("Entry.HandleWidget deactivate")
@@ -239,7 +258,9 @@ func (e *Entry) HandleWidget(ev Event) {
 		return
 	}
 
-	if _, ok := ev.(*MouseClickEvent); ok {
+	_, clicked := ev.(*MouseClickEvent)
+	_, focused := ev.(*FocusEvent)
+	if clicked || focused {
 		dprintln("Entry.HandleWidget activate")
 		e.active = true
 		e.startTextInput()
@@ -258,6 +279,8 @@ func (e *Entry) HandleWidget(ev Event) {
 		if ke, ok := ev.(*KeyReleaseEvent); ok {
 			e.HandleKeyRelease(ke)
 		}
+		e.binding.changed()
+		e.Validation.changed(before, e.Text())
 	}
 }
 
@@ -271,12 +294,23 @@ func (e *Entry) setCursor(c int) {
 	e.cursor = c
 }
 
+// textRightToLeft returns whether the text of the entry is right to left. An
+// entry without text has the direction of the entry.
+func (e *Entry) textRightToLeft() bool {
+	return textRightToLeft(e.text, e.RightToLeft())
+}
+
 func (e *Entry) HandleKeyPress(kp *KeyPressEvent) {
+	// The arrows move the cursor visually, so backwards in right to left text.
+	step := 1
+	if e.textRightToLeft() {
+		step = -1
+	}
 	switch kp.Key {
 	case KeyArrowLeft:
-		e.setCursor(e.cursor - 1)
+		e.setCursor(e.cursor - step)
 	case KeyArrowRight:
-		e.setCursor(e.cursor + 1)
+		e.setCursor(e.cursor + step)
 	case KeyHome:
 		e.setCursor(0)
 	case KeyEnd:
@@ -2,7 +2,6 @@ package ui
 
 import "time"
 
-import "golang.org/x/exp/slices"
 import "github.com/hajimehoshi/ebiten/v2/inpututil"
 import "github.com/hajimehoshi/ebiten/v2"
 
@@ -42,7 +41,7 @@ func EventIsPress(e Event) bool {
 
 // BasicEvent is the basic type of all events.
 type BasicEvent struct {
-	EventOrigin    Control // EventOrigin is the control that the event orignated in.
+	EventOrigin    Control `json:"-"` // EventOrigin is the control that the event orignated in.
 	EventModifiers         // EventModifiers are keyboard modifiers of the events.
 }
 
@@ -121,6 +120,12 @@ func (me *MouseClickEvent) EventPress() bool {
 
 type MouseButton = ebiten.MouseButton
 
+const (
+	MouseButtonLeft   = ebiten.MouseButtonLeft
+	MouseButtonRight  = ebiten.MouseButtonRight
+	MouseButtonMiddle = ebiten.MouseButtonMiddle
+)
+
 type MouseReleaseEvent struct {
 	MouseEvent
 	Button MouseButton
@@ -245,7 +250,7 @@ func (in *inputState) convertMouseInputToEvents(basic BasicEvent, window *Window
 
 	basicMouseEvent := MouseEvent{BasicEvent: basic, X: mouseX, Y: mouseY}
 
-	if mouseX != in.mouseX && mouseY != in.mouseY {
+	if mouseX != in.mouseX || mouseY != in.mouseY {
 		mouseEvent := &MouseMoveEvent{MouseEvent: basicMouseEvent}
 		mouseEvent.MoveX = mouseX - in.mouseX
 		mouseEvent.MoveY = mouseY - in.mouseY
@@ -311,69 +316,112 @@ func (in *inputState) convertTouchInputToEvents(basic BasicEvent, window *Window
 
 func convertGamepad(basic BasicEvent, id GamepadID) GamepadEvent {
 	ge := GamepadEvent{BasicEvent: basic}
+	ge.ID = id
 	ge.Name = ebiten.GamepadName(id)
 	ge.SDLID = ebiten.GamepadSDLID(id)
-	ge.ButtonCount = ebiten.GamepadButtonCount(id)
-	ge.AxisCount = ebiten.GamepadAxisCount(id)
+	ge.Standard = ebiten.IsStandardGamepadLayoutAvailable(id)
+	if ge.Standard {
+		ge.ButtonCount = int(ebiten.StandardGamepadButtonMax) + 1
+		ge.AxisCount = int(ebiten.StandardGamepadAxisMax) + 1
+	} else {
+		ge.ButtonCount = ebiten.GamepadButtonCount(id)
+		ge.AxisCount = ebiten.GamepadAxisCount(id)
+	}
 	return ge
 }
 
-func (in *inputState) convertGamepadInputToEvents(basic BasicEvent, window *Window, handle func(Event)) {
+// gamepadButtonEvent returns a button event for the button. For gamepads with
+// the standard layout the button is a standard button.
+func gamepadButtonEvent(ge GamepadEvent, button int) GamepadButtonEvent {
+	gbe := GamepadButtonEvent{GamepadEvent: ge}
+	gbe.Button = GamepadButton(button)
+	if ge.Standard {
+		gbe.StandardButton = StandardGamepadButton(button)
+	}
+	return gbe
+}
 
-	disconnected := []int{}
-	for _, id := range in.connected {
+func (in *inputState) convertGamepadInputToEvents(basic BasicEvent, window *Window, handle func(Event)) {
+	connected := in.connected[:0]
+	states := in.gamepadStates[:0]
+	for i, id := range in.connected {
+		state := in.gamepadStates[i]
 		if inpututil.IsGamepadJustDisconnected(id) {
-			ge := convertGamepad(basic, id)
-			gde := &GamepadDisconnectEvent{GamepadEvent: ge}
+			state.BasicEvent = basic
+			gde := &GamepadDisconnectEvent{GamepadEvent: state.GamepadEvent}
 			handle(gde)
-			idx := slices.Index(in.connected, id)
-			if idx >= 0 {
-				disconnected = append(disconnected, idx)
-			}
+			continue
 		}
+		connected = append(connected, id)
+		states = append(states, state)
 	}
+	in.connected = connected
+	in.gamepadStates = states
 
-	for _, idx := range disconnected {
-		in.connected = slices.Delete(in.connected, idx, idx+1)
-		in.gamepadStates = slices.Delete(in.gamepadStates, idx, idx+1)
-	}
-
-	connected := inpututil.AppendJustConnectedGamepadIDs(nil)
-	for _, id := range connected {
+	for _, id := range inpututil.AppendJustConnectedGamepadIDs(nil) {
 		state := gamepadState{GamepadEvent: convertGamepad(basic, id)}
 		state.axes = make([]float64, state.AxisCount)
 		in.gamepadStates = append(in.gamepadStates, state)
 		in.connected = append(in.connected, id)
+		gce := &GamepadConnectEvent{GamepadEvent: state.GamepadEvent}
+		handle(gce)
 	}
 
-	for _, state := range in.gamepadStates {
+	for i := range in.gamepadStates {
+		state := &in.gamepadStates[i]
 		ge := state.GamepadEvent
+		ge.BasicEvent = basic
 		id := ge.ID
-		gde := &GamepadConnectEvent{GamepadEvent: ge}
-		handle(gde)
 
-		pressed := inpututil.AppendJustPressedGamepadButtons(id, nil)
+		var pressed, released []int
+		if ge.Standard {
+			for _, button := range inpututil.AppendJustPressedStandardGamepadButtons(id, nil) {
+				pressed = append(pressed, int(button))
+			}
+			for _, button := range inpututil.AppendJustReleasedStandardGamepadButtons(id, nil) {
+				released = append(released, int(button))
+			}
+		} else {
+			for _, button := range inpututil.AppendJustPressedGamepadButtons(id, nil) {
+				pressed = append(pressed, int(button))
+			}
+			for _, button := range inpututil.AppendJustReleasedGamepadButtons(id, nil) {
+				released = append(released, int(button))
+			}
+		}
+
 		for _, button := range pressed {
-			gbe := GamepadButtonEvent{GamepadEvent: ge}
-			gbe.Button = button
-			gpe := &GamepadButtonPressEvent{GamepadButtonEvent: gbe}
+			gpe := &GamepadButtonPressEvent{GamepadButtonEvent: gamepadButtonEvent(ge, button)}
 			handle(gpe)
 		}
 
-		released := inpututil.AppendJustReleasedGamepadButtons(id, nil)
 		for _, button := range released {
-			gbe := GamepadButtonEvent{GamepadEvent: ge}
-			gbe.Button = button
-			gpe := &GamepadButtonReleaseEvent{GamepadButtonEvent: gbe}
-			gpe.Duration = convertDuration(inpututil.GamepadButtonPressDuration(id, button))
-			handle(gpe)
+			gre := &GamepadButtonReleaseEvent{GamepadButtonEvent: gamepadButtonEvent(ge, button)}
+			if ge.Standard {
+				gre.Duration = convertDuration(inpututil.StandardGamepadButtonPressDuration(id, StandardGamepadButton(button)))
+			} else {
+				gre.Duration = convertDuration(inpututil.GamepadButtonPressDuration(id, GamepadButton(button)))
+			}
+			handle(gre)
 		}
 
-		for axis := 0; axis < state.AxisCount; axis++ {
+		for axis := 0; axis < state.AxisCount && axis < len(state.axes); axis++ {
+			var value float64
+			if ge.Standard {
+				value = ebiten.StandardGamepadAxisValue(id, StandardGamepadAxis(axis))
+			} else {
+				value = ebiten.GamepadAxisValue(id, axis)
+			}
 			old := state.axes[axis]
-			value := ebiten.GamepadAxis(id, axis)
 			if value != old {
 				gae := &GamepadAxisEvent{GamepadEvent: ge}
+				gae.Axis = axis
+				if ge.Standard {
+					gae.StandardAxis = StandardGamepadAxis(axis)
+				}
+				gae.Value = value
+				gae.Previous = old
+				state.axes[axis] = value
 				handle(gae)
 			}
 		}
@@ -388,15 +436,20 @@ func (in *inputState) convertInputToEvents(window *Window, handle func(Event)) {
 	// Update state
 	basic := BasicEvent{EventOrigin: window}
 
-	// Send an update event every time.
-	update := &UpdateEvent{BasicEvent: basic}
-	update.Duration = convertDuration(1) // one tick
-	handle(update)
-
 	in.convertKeyboardInputToEvents(&basic, window, handle)
 	in.convertMouseInputToEvents(basic, window, handle)
 	in.convertTouchInputToEvents(basic, window, handle)
+	in.convertGamepadInputToEvents(basic, window, handle)
+
+}
 
+// InputEvents implements InputSource by polling the ebiten input.
+func (in *inputState) InputEvents(window *Window) []Event {
+	var events []Event
+	in.convertInputToEvents(window, func(e Event) {
+		events = append(events, e)
+	})
+	return events
 }
 
 // AwayEvent is sent to notify a child widget that the fous has gone away from
@@ -429,6 +482,7 @@ type GamepadEvent struct {
 	Name        string
 	SDLID       string
 	AxisCount   int
+	Standard    bool // Standard is true if the gamepad has the standard layout.
 }
 
 type GamepadConnectEvent struct {
@@ -439,16 +493,22 @@ type GamepadDisconnectEvent struct {
 	GamepadEvent
 }
 
+// GamepadButtonEvent is the base of the gamepad button events.
+// For a gamepad with the standard layout, Button is the same as StandardButton.
 type GamepadButtonEvent struct {
 	GamepadEvent
 	Button         GamepadButton
 	StandardButton StandardGamepadButton
 }
 
+// GamepadAxisEvent is sent when the value of an axis of a gamepad changes.
+// For a gamepad with the standard layout, Axis is the same as StandardAxis.
 type GamepadAxisEvent struct {
 	GamepadEvent
 	Axis         int
 	StandardAxis StandardGamepadAxis
+	Value        float64 // Value is the value of the axis, between -1 and 1.
+	Previous     float64 // Previous is the previous value of the axis.
 }
 
 type GamepadButtonPressEvent struct {
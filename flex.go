@@ -0,0 +1,391 @@
+package ui
+
+// FlexDirection is the main axis of a FlexLayout.
+type FlexDirection int
+
+const (
+	FlexRow    FlexDirection = iota // FlexRow places the children from left to right.
+	FlexColumn                      // FlexColumn places the children from top to bottom.
+)
+
+// FlexJustify is how a FlexLayout distributes the free space along the main
+// axis, like justify-content in CSS.
+type FlexJustify int
+
+const (
+	FlexJustifyStart        FlexJustify = iota // Pack the children at the start.
+	FlexJustifyEnd                             // Pack the children at the end.
+	FlexJustifyCenter                          // Pack the children in the center.
+	FlexJustifySpaceBetween                    // Equal space between the children.
+	FlexJustifySpaceAround                     // Equal space around each child.
+	FlexJustifySpaceEvenly                     // Equal space between and around the children.
+)
+
+// FlexAlign is how a FlexLayout aligns the children on the cross axis of a
+// line, like align-items in CSS.
+type FlexAlign int
+
+const (
+	FlexAlignStart   FlexAlign = iota // Align the children at the start.
+	FlexAlignEnd                      // Align the children at the end.
+	FlexAlignCenter                   // Align the children in the center.
+	FlexAlignStretch                  // Stretch the children to the size of the line.
+)
+
+// FlexItem are the flex properties of a child of a FlexLayout.
+type FlexItem struct {
+	// Grow is the share of the free space the child grows with.
+	Grow float64
+	// Shrink is the share of the lacking space the child shrinks with,
+	// in proportion to its basis.
+	Shrink float64
+	// Basis is the size of the child on the main axis before growing or
+	// shrinking. If it is 0 the size of the child is used.
+	Basis int
+}
+
+// DefaultFlexItem are the flex properties of a child for which none were
+// set. The child does not grow but it may shrink.
+var DefaultFlexItem = FlexItem{Shrink: 1}
+
+// FlexLayout is a Layout that places the children along a main axis, and
+// lets them grow or shrink to fill the available space, like the flexible
+// box layout in CSS. If there is no free space to distribute, the layout
+// takes only the space the children need.
+type FlexLayout struct {
+	Direction FlexDirection
+	Justify   FlexJustify
+	Align     FlexAlign
+	Gap       int  // Gap is the space between the children and the lines.
+	Wrap      bool // Wrap places the children on multiple lines if needed.
+	items     map[Control]FlexItem
+}
+
+// NewFlexLayout returns a flex layout in the direction.
+func NewFlexLayout(direction FlexDirection) *FlexLayout {
+	return &FlexLayout{Direction: direction}
+}
+
+// Item returns the flex properties of the child.
+func (l *FlexLayout) Item(child Control) FlexItem {
+	if item, ok := l.items[child]; ok {
+		return item
+	}
+	return DefaultFlexItem
+}
+
+// SetItem sets the flex properties of the child.
+func (l *FlexLayout) SetItem(child Control, item FlexItem) {
+	if l.items == nil {
+		l.items = map[Control]FlexItem{}
+	}
+	l.items[child] = item
+}
+
+// DeleteItem deletes the flex properties of the child.
+func (l *FlexLayout) DeleteItem(child Control) {
+	delete(l.items, child)
+}
+
+// axes converts a width and height to a main and cross size, or back.
+func (l *FlexLayout) axes(a, b int) (int, int) {
+	if l.Direction == FlexColumn {
+		return b, a
+	}
+	return a, b
+}
+
+// flexEntry is a child during the layout.
+type flexEntry struct {
+	child Control
+	item  FlexItem
+	base  int // base is the size on the main axis before flexing.
+	main  int // main is the size on the main axis after flexing.
+	cross int // cross is the size on the cross axis.
+}
+
+// flexLine is a line of children.
+type flexLine struct {
+	entries []*flexEntry
+	cross   int // cross is the size of the line on the cross axis.
+}
+
+// Layout lays out the children with the flex properties.
+func (l *FlexLayout) Layout(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int) {
+	mainSpace, crossSpace := l.axes(width, height)
+
+	var entries []*flexEntry
+	for _, child := range children {
+		if child.Hidden() {
+			continue
+		}
+		entry := &flexEntry{child: child, item: l.Item(child)}
+		child.LayoutWidget(l.axes(mainSpace, crossSpace))
+		entry.base, entry.cross = l.axes(child.WidgetSize())
+		if entry.item.Basis > 0 {
+			entry.base = entry.item.Basis
+		}
+		entries = append(entries, entry)
+	}
+
+	lines := l.breakLines(entries, mainSpace)
+
+	fill := false
+	if layoutBounded(mainSpace) {
+		fill = l.Justify != FlexJustifyStart
+		for _, entry := range entries {
+			fill = fill || entry.item.Grow > 0
+		}
+	}
+
+	mainSize, crossSize := 0, 0
+	for i, line := range lines {
+		used := l.flexLine(line, mainSpace)
+		for _, entry := range line.entries {
+			entry.child.LayoutWidget(l.axes(entry.main, crossSpace))
+			// Grow the child if it laid itself out smaller.
+			width, height := l.axes(entry.main, 0)
+			stretchWidget(entry.child, width, height)
+			_, entry.cross = l.axes(entry.child.WidgetSize())
+			line.cross = max(line.cross, entry.cross)
+		}
+		mainSize = max(mainSize, used)
+		if i > 0 {
+			crossSize += l.Gap
+		}
+		crossSize += line.cross
+	}
+	if fill {
+		mainSize = max(mainSize, mainSpace)
+	}
+
+	cross := 0
+	for _, line := range lines {
+		l.placeLine(line, mainSize, cross)
+		cross += line.cross + l.Gap
+	}
+	return l.axes(mainSize, crossSize)
+}
+
+// breakLines puts the entries on lines that fit in the main space.
+func (l *FlexLayout) breakLines(entries []*flexEntry, mainSpace int) []*flexLine {
+	var lines []*flexLine
+	var line *flexLine
+	used := 0
+	for _, entry := range entries {
+		wrap := l.Wrap && layoutBounded(mainSpace) && line != nil &&
+			used+l.Gap+entry.base > mainSpace
+		if line == nil || wrap {
+			line = &flexLine{}
+			lines = append(lines, line)
+			used = entry.base
+		} else {
+			used += l.Gap + entry.base
+		}
+		line.entries = append(line.entries, entry)
+	}
+	return lines
+}
+
+// flexLine grows or shrinks the entries of the line to fill the main space,
+// and returns the used main size.
+func (l *FlexLayout) flexLine(line *flexLine, mainSpace int) (used int) {
+	grow, shrink := 0.0, 0.0
+	used = l.Gap * (len(line.entries) - 1)
+	for _, entry := range line.entries {
+		entry.main = entry.base
+		used += entry.base
+		grow += entry.item.Grow
+		shrink += entry.item.Shrink * float64(entry.base)
+	}
+	if !layoutBounded(mainSpace) {
+		return used
+	}
+	free := mainSpace - used
+	// The shares are rounded down, so the last entry that grows or shrinks
+	// gets what is left, and the line fills the main space exactly.
+	rest := free
+	var last *flexEntry
+	if free > 0 && grow > 0 {
+		for _, entry := range line.entries {
+			if entry.item.Grow <= 0 {
+				continue
+			}
+			share := int(float64(free) * entry.item.Grow / grow)
+			entry.main += share
+			rest -= share
+			last = entry
+		}
+		last.main += rest
+	} else if free < 0 && shrink > 0 {
+		for _, entry := range line.entries {
+			if entry.item.Shrink <= 0 || entry.base <= 0 {
+				continue
+			}
+			share := int(float64(free) * entry.item.Shrink * float64(entry.base) / shrink)
+			entry.main = max(1, entry.main+share)
+			rest -= share
+			last = entry
+		}
+		last.main = max(1, last.main+rest)
+	} else {
+		return used
+	}
+	used = l.Gap * (len(line.entries) - 1)
+	for _, entry := range line.entries {
+		used += entry.main
+	}
+	return used
+}
+
+// placeLine moves the entries of the line to their position, with the line
+// starting at cross on the cross axis.
+func (l *FlexLayout) placeLine(line *flexLine, mainSize, cross int) {
+	n := len(line.entries)
+	used := l.Gap * (n - 1)
+	for _, entry := range line.entries {
+		entry.main, _ = l.axes(entry.child.WidgetSize())
+		used += entry.main
+	}
+	free := max(0, mainSize-used)
+
+	pos, space := 0, 0
+	switch l.Justify {
+	case FlexJustifyEnd:
+		pos = free
+	case FlexJustifyCenter:
+		pos = free / 2
+	case FlexJustifySpaceBetween:
+		if n > 1 {
+			space = free / (n - 1)
+		}
+	case FlexJustifySpaceAround:
+		space = free / n
+		pos = space / 2
+	case FlexJustifySpaceEvenly:
+		space = free / (n + 1)
+		pos = space
+	}
+
+	for _, entry := range line.entries {
+		offset := 0
+		switch l.Align {
+		case FlexAlignEnd:
+			offset = line.cross - entry.cross
+		case FlexAlignCenter:
+			offset = (line.cross - entry.cross) / 2
+		case FlexAlignStretch:
+			width, height := l.axes(0, line.cross)
+			stretchWidget(entry.child, width, height)
+		}
+		entry.child.MoveWidget(l.axes(pos, cross+offset))
+		pos += entry.main + l.Gap + space
+	}
+}
+
+var _ Layout = &FlexLayout{}
+
+// Flex is a container that lays out its children with a FlexLayout. It can
+// be used for tool bars with a spacer that grows, or for forms where the
+// entries stretch to the available width.
+type Flex struct {
+	BasicContainer
+	layout *FlexLayout
+}
+
+// NewFlex returns a flex container in the direction.
+func NewFlex(direction FlexDirection) *Flex {
+	f := &Flex{layout: NewFlexLayout(direction)}
+	f.controls = []Control{}
+	return f
+}
+
+// FlexLayout returns the layout of the container. Call NeedLayout after
+// changing it directly.
+func (f *Flex) FlexLayout() *FlexLayout {
+	return f.layout
+}
+
+// Append appends a child with the default flex properties.
+func (f *Flex) Append(c Control) {
+	f.BasicContainer.AppendWithParent(c, f)
+}
+
+// AppendItem appends a child with the flex properties.
+func (f *Flex) AppendItem(c Control, item FlexItem) {
+	f.layout.SetItem(c, item)
+	f.Append(c)
+}
+
+// SetItem sets the flex properties of the child.
+func (f *Flex) SetItem(c Control, item FlexItem) {
+	f.layout.SetItem(c, item)
+	NeedLayout(f)
+}
+
+func (f *Flex) Delete(index int) {
+	if index < 0 || index >= len(f.controls) {
+		return
+	}
+	child := f.controls[index]
+	f.layout.DeleteItem(child)
+	f.BasicContainer.Delete(index)
+	child.SetParent(nil)
+}
+
+func (f *Flex) SetDirection(direction FlexDirection) {
+	f.layout.Direction = direction
+	NeedLayout(f)
+}
+
+func (f *Flex) SetJustify(justify FlexJustify) {
+	f.layout.Justify = justify
+	NeedLayout(f)
+}
+
+func (f *Flex) SetAlign(align FlexAlign) {
+	f.layout.Align = align
+	NeedLayout(f)
+}
+
+func (f *Flex) SetGap(gap int) {
+	f.layout.Gap = gap
+	NeedLayout(f)
+}
+
+func (f *Flex) SetWrap(wrap bool) {
+	f.layout.Wrap = wrap
+	NeedLayout(f)
+}
+
+func (f *Flex) Destroy() {
+	for _, child := range f.controls {
+		child.SetParent(nil)
+		child.Destroy()
+	}
+}
+
+// LayoutWidget lays out the children with the flex layout, inside of the
+// margins.
+func (f *Flex) LayoutWidget(width, height int) {
+	margin := f.Style().Margin.Int()
+	lw, lh := f.layout.Layout(f, width-margin*2, height-margin*2, f.controls...)
+	for _, child := range f.controls {
+		x, y := child.WidgetAt()
+		child.MoveWidget(x+margin, y+margin)
+	}
+	f.width = lw + margin*2
+	f.height = lh + margin*2
+	f.mirrorChildren(f.width)
+	f.ClipTo(width, height)
+	f.BasicContainer.UpdateOrdered()
+}
+
+func (f Flex) DrawWidget(g *Graphic) {
+	dx, dy := f.WidgetAbsolute()
+	FillFrameStyle(g, dx, dy, f.width, f.height, f.Style())
+	f.BasicContainer.DrawWidget(g)
+	f.DrawDebug(g, "FLX")
+}
+
+var _ Control = &Flex{}
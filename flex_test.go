@@ -0,0 +1,113 @@
+package ui
+
+import "testing"
+
+// flexChild is a widget with a preferred size, that takes less if less is
+// available.
+type flexChild struct {
+	BasicWidget
+	size [2]int
+}
+
+func newFlexChild(width, height int) *flexChild {
+	return &flexChild{size: [2]int{width, height}}
+}
+
+func (c *flexChild) LayoutWidget(width, height int) {
+	c.width, c.height = min(c.size[0], width), min(c.size[1], height)
+}
+
+// layoutFlex lays out children of the sizes with the layout in width by
+// height, and returns the position and size of each child.
+func layoutFlex(l *FlexLayout, width, height int, sizes [][2]int, items []FlexItem) [][4]int {
+	var children []Control
+	for i, size := range sizes {
+		child := newFlexChild(size[0], size[1])
+		if i < len(items) {
+			l.SetItem(child, items[i])
+		}
+		children = append(children, child)
+	}
+	l.Layout(nil, width, height, children...)
+	var bounds [][4]int
+	for _, child := range children {
+		x, y := child.WidgetAt()
+		w, h := child.WidgetSize()
+		bounds = append(bounds, [4]int{x, y, w, h})
+	}
+	return bounds
+}
+
+func TestFlexLayout(t *testing.T) {
+	grow := FlexItem{Grow: 1}
+	tests := []struct {
+		name   string
+		layout FlexLayout
+		width  int
+		sizes  [][2]int
+		items  []FlexItem
+		want   [][4]int
+	}{
+		{"grow", FlexLayout{}, 100, [][2]int{{10, 10}, {10, 10}, {10, 10}},
+			[]FlexItem{grow, grow, grow},
+			[][4]int{{0, 0, 33, 10}, {33, 0, 33, 10}, {66, 0, 34, 10}}},
+		{"grow remainder", FlexLayout{}, 10, [][2]int{{0, 10}, {0, 10}, {0, 10}},
+			[]FlexItem{grow, grow, grow},
+			[][4]int{{0, 0, 3, 10}, {3, 0, 3, 10}, {6, 0, 4, 10}}},
+		{"grow one", FlexLayout{Gap: 5}, 100, [][2]int{{10, 10}, {10, 10}, {10, 10}},
+			[]FlexItem{DefaultFlexItem, grow},
+			[][4]int{{0, 0, 10, 10}, {15, 0, 70, 10}, {90, 0, 10, 10}}},
+		{"shrink", FlexLayout{}, 20, [][2]int{{10, 10}, {10, 10}, {10, 10}}, nil,
+			[][4]int{{0, 0, 7, 10}, {7, 0, 7, 10}, {14, 0, 6, 10}}},
+		{"shrink by basis", FlexLayout{}, 30, [][2]int{{40, 10}, {20, 10}}, nil,
+			[][4]int{{0, 0, 18, 10}, {18, 0, 12, 10}}},
+		{"no shrink", FlexLayout{}, 15, [][2]int{{10, 10}, {10, 10}},
+			[]FlexItem{{}, DefaultFlexItem},
+			[][4]int{{0, 0, 10, 10}, {10, 0, 5, 10}}},
+		{"justify start", FlexLayout{Justify: FlexJustifyStart}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{0, 0, 10, 10}, {10, 0, 10, 10}}},
+		{"justify end", FlexLayout{Justify: FlexJustifyEnd}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{80, 0, 10, 10}, {90, 0, 10, 10}}},
+		{"justify center", FlexLayout{Justify: FlexJustifyCenter}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{40, 0, 10, 10}, {50, 0, 10, 10}}},
+		{"justify space between", FlexLayout{Justify: FlexJustifySpaceBetween}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{0, 0, 10, 10}, {90, 0, 10, 10}}},
+		{"justify space around", FlexLayout{Justify: FlexJustifySpaceAround}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{20, 0, 10, 10}, {70, 0, 10, 10}}},
+		{"justify space evenly", FlexLayout{Justify: FlexJustifySpaceEvenly}, 100, [][2]int{{10, 10}, {10, 10}}, nil,
+			[][4]int{{26, 0, 10, 10}, {62, 0, 10, 10}}},
+		{"align end", FlexLayout{Align: FlexAlignEnd}, 100, [][2]int{{10, 10}, {10, 20}}, nil,
+			[][4]int{{0, 10, 10, 10}, {10, 0, 10, 20}}},
+		{"align center", FlexLayout{Align: FlexAlignCenter}, 100, [][2]int{{10, 10}, {10, 20}}, nil,
+			[][4]int{{0, 5, 10, 10}, {10, 0, 10, 20}}},
+		{"align stretch", FlexLayout{Align: FlexAlignStretch}, 100, [][2]int{{10, 10}, {10, 20}}, nil,
+			[][4]int{{0, 0, 10, 20}, {10, 0, 10, 20}}},
+		{"column", FlexLayout{Direction: FlexColumn, Gap: 2}, 100, [][2]int{{10, 10}, {20, 10}}, nil,
+			[][4]int{{0, 0, 10, 10}, {0, 12, 20, 10}}},
+		{"wrap", FlexLayout{Wrap: true, Gap: 5}, 25, [][2]int{{10, 10}, {10, 20}, {10, 10}}, nil,
+			[][4]int{{0, 0, 10, 10}, {15, 0, 10, 20}, {0, 25, 10, 10}}},
+		{"wrap grow", FlexLayout{Wrap: true}, 25, [][2]int{{10, 10}, {10, 10}, {10, 10}},
+			[]FlexItem{grow, grow, grow},
+			[][4]int{{0, 0, 12, 10}, {12, 0, 13, 10}, {0, 10, 25, 10}}},
+	}
+	for _, tt := range tests {
+		layout := tt.layout
+		got := layoutFlex(&layout, tt.width, 100, tt.sizes, tt.items)
+		for i, want := range tt.want {
+			if got[i] != want {
+				t.Errorf("%s: child %d at %v, want %v", tt.name, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestFlexLayoutUnlimited(t *testing.T) {
+	l := NewFlexLayout(FlexRow)
+	l.Gap = 4
+	children := []Control{newFlexChild(10, 10), newFlexChild(20, 30)}
+	l.SetItem(children[0], FlexItem{Grow: 1})
+	width, height := l.Layout(nil, LayoutUnlimited, LayoutUnlimited, children...)
+	if width != 34 || height != 30 {
+		t.Errorf("size %dx%d, want 34x30", width, height)
+	}
+}
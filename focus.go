@@ -66,3 +66,37 @@ func HandleContainerIfNeeded(e Event, c Container) (used bool) {
 	// Not handled.
 	return false
 }
+
+// HasChild is implemented by widgets that have a single child widget,
+// such as Window, Pane and Group.
+type HasChild interface {
+	Child() Control
+}
+
+// FocusedControl returns the innermost control below c that has the focus.
+// It follows the focus of the containers and the child of widgets that
+// have a single child. If nothing below c has the focus, c is returned.
+func FocusedControl(c Control) Control {
+	for c != nil {
+		if w, ok := c.(*Window); ok {
+			root := w.focusRoot()
+			if root == nil {
+				return c
+			}
+			c = root
+			continue
+		}
+		if focus := c.Focus(); focus != nil && !focus.Hidden() {
+			c = focus
+			continue
+		}
+		if hc, ok := c.(HasChild); ok {
+			if child := hc.Child(); child != nil && !child.Hidden() {
+				c = child
+				continue
+			}
+		}
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package ui
+
+import "fmt"
+import "image"
+import "image/color"
+import "image/png"
+import "log"
+import "os"
+import "path/filepath"
+import "strconv"
+import "strings"
+
+// Golden image mode is enabled by setting the EBUI_GOLDEN environment
+// variable to a directory. In that mode Init initializes the library headless
+// like TestInit, and Main does not open a window, but draws the window once
+// with the software renderer and compares it with the golden image
+// <directory>/<scenario>.png. The scenario name is EBUI_GOLDEN_NAME if set,
+// otherwise the name of the program. All text is drawn with the font
+// goldenFontName, so the images don't depend on the installed fonts.
+//
+// If EBUI_GOLDEN_UPDATE=1 the golden image is written in stead.
+// EBUI_GOLDEN_TOLERANCE sets the per pixel tolerance, the default is
+// GoldenTolerance. On failure the rendered image and a diff image are written
+// next to the golden image as <scenario>.got.png and <scenario>.diff.png,
+// and the program exits with status 1.
+const (
+	goldenEnv          = "EBUI_GOLDEN"
+	goldenNameEnv      = "EBUI_GOLDEN_NAME"
+	goldenUpdateEnv    = "EBUI_GOLDEN_UPDATE"
+	goldenToleranceEnv = "EBUI_GOLDEN_TOLERANCE"
+)
+
+// GoldenTolerance is the default per pixel tolerance for golden images.
+// It is the maximum difference allowed for each color channel.
+const GoldenTolerance = 2
+
+func goldenDir() string {
+	return os.Getenv(goldenEnv)
+}
+
+func goldenName() string {
+	if name := os.Getenv(goldenNameEnv); name != "" {
+		return name
+	}
+	name := filepath.Base(os.Args[0])
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func goldenTolerance() int {
+	if v, ok := os.LookupEnv(goldenToleranceEnv); ok {
+		if tolerance, err := strconv.Atoi(v); err == nil {
+			return tolerance
+		}
+		log.Printf("golden: bad tolerance %q", v)
+	}
+	return GoldenTolerance
+}
+
+// mainGolden draws the window with the software renderer and checks it
+// against the golden image. It returns the exit status.
+func (w *Window) mainGolden(dir string) int {
+	w.LayoutWidget(w.width, w.height)
+	img := RenderSoftware(w, w.width, w.height)
+	name := filepath.Join(dir, goldenName()+".png")
+	update := os.Getenv(goldenUpdateEnv) == "1"
+	if err := CheckGolden(img, name, goldenTolerance(), update); err != nil {
+		log.Printf("golden: %s", err)
+		return 1
+	}
+	log.Printf("golden: %s ok", name)
+	return 0
+}
+
+// CompareImages compares got with want pixel by pixel. A pixel is different
+// if one of its color channels differs by more than tolerance. It returns the
+// number of different pixels, and a diff image that shows a faded copy of want
+// with the different pixels in red. Pixels that are only in one of the images
+// are also different.
+func CompareImages(got, want Image, tolerance int) (different int, diff *image.RGBA) {
+	gb, wb := got.Bounds(), want.Bounds()
+	w := max(gb.Dx(), wb.Dx())
+	h := max(gb.Dy(), wb.Dy())
+	diff = image.NewRGBA(image.Rect(0, 0, w, h))
+	red := color.RGBA{255, 0, 0, 255}
+	limit := uint32(tolerance) * 0x101
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			gp := image.Pt(gb.Min.X+x, gb.Min.Y+y)
+			wp := image.Pt(wb.Min.X+x, wb.Min.Y+y)
+			if !gp.In(gb) || !wp.In(wb) {
+				diff.SetRGBA(x, y, red)
+				different++
+				continue
+			}
+			gr, gg, gbl, ga := got.At(gp.X, gp.Y).RGBA()
+			wr, wg, wbl, wa := want.At(wp.X, wp.Y).RGBA()
+			if channelDiff(gr, wr) > limit || channelDiff(gg, wg) > limit ||
+				channelDiff(gbl, wbl) > limit || channelDiff(ga, wa) > limit {
+				diff.SetRGBA(x, y, red)
+				different++
+				continue
+			}
+			diff.SetRGBA(x, y, color.RGBA{
+				uint8(wr >> 10), uint8(wg >> 10), uint8(wbl >> 10), uint8(wa >> 8),
+			})
+		}
+	}
+	return different, diff
+}
+
+func channelDiff(a, b uint32) uint32 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// CheckGolden compares img with the golden PNG image in the file name,
+// with the given tolerance per pixel, as in CompareImages. If update is true,
+// img is written to the file in stead. If the images differ, img and the diff
+// image are written next to the golden image with the .got.png and .diff.png
+// extensions, and an error is returned.
+func CheckGolden(img Image, name string, tolerance int, update bool) error {
+	if update {
+		return writePNG(name, img)
+	}
+
+	want, err := readPNG(name)
+	if err != nil {
+		return fmt.Errorf("cannot read golden image: %w", err)
+	}
+
+	different, diff := CompareImages(img, want, tolerance)
+	if different == 0 {
+		return nil
+	}
+
+	base := strings.TrimSuffix(name, ".png")
+	if err := writePNG(base+".got.png", img); err != nil {
+		return err
+	}
+	if err := writePNG(base+".diff.png", diff); err != nil {
+		return err
+	}
+	return fmt.Errorf("%s: %d pixels differ, see %s.diff.png", name, different, base)
+}
+
+func readPNG(name string) (Image, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(name string, img Image) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// goldenImage returns an image of width by height filled with col.
+func goldenImage(width, height int, col color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, col)
+		}
+	}
+	return img
+}
+
+func TestCompareImages(t *testing.T) {
+	gray := color.RGBA{100, 100, 100, 255}
+	tests := []struct {
+		name      string
+		got, want *image.RGBA
+		tolerance int
+		different int
+	}{
+		{"same", goldenImage(4, 3, gray), goldenImage(4, 3, gray), 0, 0},
+		{"within tolerance", goldenImage(4, 3, color.RGBA{102, 98, 100, 255}), goldenImage(4, 3, gray), 2, 0},
+		{"beyond tolerance", goldenImage(4, 3, color.RGBA{103, 100, 100, 255}), goldenImage(4, 3, gray), 2, 12},
+		{"larger", goldenImage(5, 3, gray), goldenImage(4, 3, gray), 0, 3},
+		{"smaller", goldenImage(4, 2, gray), goldenImage(4, 3, gray), 0, 4},
+	}
+	for _, tt := range tests {
+		different, diff := CompareImages(tt.got, tt.want, tt.tolerance)
+		if different != tt.different {
+			t.Errorf("%s: %d pixels differ, want %d", tt.name, different, tt.different)
+		}
+		// The last pixel differs in all the cases that differ.
+		last := diff.Bounds().Max.Sub(image.Pt(1, 1))
+		if tt.different > 0 && diff.RGBAAt(last.X, last.Y) != (color.RGBA{255, 0, 0, 255}) {
+			t.Errorf("%s: diff pixel %v, want red", tt.name, diff.RGBAAt(last.X, last.Y))
+		}
+	}
+}
+
+func TestCheckGolden(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "scenario.png")
+	img := goldenImage(8, 8, color.RGBA{10, 20, 30, 255})
+
+	err := CheckGolden(img, name, GoldenTolerance, false)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("missing golden image: %v, want a not exist error", err)
+	}
+	if err := CheckGolden(img, name, GoldenTolerance, true); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := CheckGolden(img, name, GoldenTolerance, false); err != nil {
+		t.Errorf("same image: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scenario.got.png")); err == nil {
+		t.Error("same image wrote scenario.got.png")
+	}
+
+	img.SetRGBA(3, 4, color.RGBA{255, 255, 255, 255})
+	if err := CheckGolden(img, name, GoldenTolerance, false); err == nil {
+		t.Error("changed image: no error")
+	}
+	for _, file := range []string{"scenario.got.png", "scenario.diff.png"} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			t.Errorf("changed image: %v", err)
+		}
+	}
+}
+
+// TestGoldenWidgets renders widgets that draw no text with the software
+// renderer, so the result does not depend on the fonts. Even an empty
+// checkbox is as high as a line of text, so it is left out. Run it with
+// EBUI_GOLDEN_UPDATE=1 to update test/golden/widgets.png.
+func TestGoldenWidgets(t *testing.T) {
+	left, right := NewScroller(0, 10), NewScroller(0, 10)
+	left.SetValue(3)
+	right.SetValue(8)
+	inner := NewSplitter(true, NewSlab(), NewSlab())
+	inner.SetRatio(0.4)
+
+	dock := NewDock()
+	dock.Append(left, DockLeft)
+	dock.Append(right, DockRight)
+	dock.Append(NewSplitter(false, NewSlab(), inner), DockCenter)
+
+	dock.LayoutWidget(160, 100)
+	img := RenderSoftware(dock, 160, 100)
+	update := os.Getenv(goldenUpdateEnv) == "1"
+	if err := CheckGolden(img, "test/golden/widgets.png", GoldenTolerance, update); err != nil {
+		t.Error(err)
+	}
+}
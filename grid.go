@@ -5,10 +5,12 @@ import "golang.org/x/exp/slices"
 // Mesh is an element of a Grid.
 type Mesh struct {
 	Control
-	align StyleAlign
-	left  int
-	top   int
-	span  int
+	align   StyleAlign
+	valign  StyleAlign // valign is the vertical alignment, left is the top.
+	left    int
+	top     int
+	span    int
+	rowSpan int
 }
 
 func NewMesh(child Control, left, top, span int, align StyleAlign) *Mesh {
@@ -17,10 +19,33 @@ func NewMesh(child Control, left, top, span int, align StyleAlign) *Mesh {
 	}
 	mesh := &Mesh{Control: child,
 		left: left, top: top,
-		span: span, align: align}
+		span: span, rowSpan: 1, align: align}
 	return mesh
 }
 
+// SetRowSpan makes the mesh span multiple rows. It must be called before the
+// mesh is appended to a grid, otherwise use Grid.MergeRows.
+func (m *Mesh) SetRowSpan(rowSpan int) *Mesh {
+	if rowSpan < 1 {
+		panic("SetRowSpan: out of range")
+	}
+	m.rowSpan = rowSpan
+	return m
+}
+
+// SetVerticalAlign sets the vertical alignment of the mesh in its cell.
+// StyleAlignStart is the top, StyleAlignEnd the bottom, and
+// StyleAlignJustify stretches the widget to the height of the cell.
+func (m *Mesh) SetVerticalAlign(valign StyleAlign) *Mesh {
+	m.valign = valign
+	return m
+}
+
+// rows returns the amount of rows the mesh spans.
+func (m *Mesh) rows() int {
+	return max(1, m.rowSpan)
+}
+
 func (a StyleAlign) Position(value, size, space int) int {
 	switch a {
 	case StyleAlignRight:
@@ -45,17 +70,75 @@ func (m *Mesh) MoveStyleAligned(x, y, width, height int) {
 	MoveWidgetStyleAligned(m.Control, x, y, width, height, m.align)
 }
 
-// A Grid is a grid of equally spaced columns with rows of varying height.
-// The widgets in the grid are layed out in rows of columns of fixed space.
-// It is possible to make wigets span multiple columns.
+// place moves the widget of the mesh in the cell, aligned horizontally and
+// vertically. With StyleAlignJustify the widget is stretched to the cell.
+func (m *Mesh) place(x, y, width, height int) {
+	if m.align == StyleAlignJustify {
+		stretchWidget(m.Control, width, 0)
+	}
+	if m.valign == StyleAlignJustify {
+		stretchWidget(m.Control, 0, height)
+	}
+	widgetWidth, widgetHeight := m.Control.WidgetSize()
+	x = m.align.Position(x, widgetWidth, width)
+	y = m.valign.Position(y, widgetHeight, height)
+	m.Control.MoveWidget(x, y)
+}
+
+// GridSizing is how the width of a column of a Grid is determined.
+type GridSizing int
+
+const (
+	// GridWeighted columns share the width that the other columns leave,
+	// in proportion to their weight.
+	GridWeighted GridSizing = iota
+	// GridFixed columns have a fixed width.
+	GridFixed
+	// GridAuto columns are as wide as their widest widget.
+	GridAuto
+)
+
+// GridColumn is the sizing of a column of a Grid. By default, the columns
+// are weighted with a weight of 1, so they all have the same width.
+type GridColumn struct {
+	Sizing GridSizing
+	Width  int     // Width is the width of a fixed column.
+	Weight float64 // Weight is the weight of a weighted column.
+}
+
+// FixedColumn returns the sizing of a column with a fixed width.
+func FixedColumn(width int) GridColumn {
+	return GridColumn{Sizing: GridFixed, Width: width}
+}
+
+// AutoColumn returns the sizing of a column that is as wide as its widest
+// widget. Widgets that span multiple columns are not taken into account.
+func AutoColumn() GridColumn {
+	return GridColumn{Sizing: GridAuto}
+}
+
+// WeightedColumn returns the sizing of a column that takes a share of the
+// remaining width in proportion to weight.
+func WeightedColumn(weight float64) GridColumn {
+	return GridColumn{Sizing: GridWeighted, Weight: weight}
+}
+
+// A Grid is a grid of columns with rows of varying height.
+// The widgets in the grid are layed out in rows of columns. By default, the
+// columns are equally spaced, but they can also have a fixed width, be as
+// wide as their contents, or have a weighted share of the width, see
+// SetColumn. It is possible to make wigets span multiple columns and rows.
 // It is also possible to align the Mesh in the columns to the start, center
-// or end of the column they are in.
+// or end of the column they are in, and vertically in the rows.
 // Grid will automatically expand vertically but not horizontally.
 type Grid struct {
 	BasicContainer
-	meshes  []*Mesh
-	rows    int
-	columns int
+	meshes       []*Mesh
+	rows         int
+	columns      int
+	columnSizing []GridColumn // columnSizing is the sizing of the columns, if set.
+	rowGap       int          // rowGap is the space between the rows.
+	columnGap    int          // columnGap is the space between the columns.
 }
 
 func (g *Grid) Destroy() {
@@ -114,6 +197,62 @@ func (g *Grid) Merge(left, top, span int) {
 	mesh.span = span
 }
 
+// MergeRows makes the mesh at left and top span the amount of rows.
+func (g *Grid) MergeRows(left, top, rows int) {
+	if rows < 1 {
+		panic("MergeRows in grid: span out of range")
+	}
+	mesh := g.getMesh(left, top)
+	if mesh == nil {
+		panic("MergeRows in grid out of range")
+	}
+	mesh.rowSpan = rows
+	g.rows = max(g.rows, top+rows)
+	NeedLayout(g)
+}
+
+// SetVerticalAlign sets the vertical alignment of the mesh at left and top.
+func (g *Grid) SetVerticalAlign(left, top int, valign StyleAlign) {
+	mesh := g.getMesh(left, top)
+	if mesh == nil {
+		panic("SetVerticalAlign in grid out of range")
+	}
+	mesh.valign = valign
+	NeedLayout(g)
+}
+
+// SetColumn sets the sizing of the column with the index.
+func (g *Grid) SetColumn(index int, column GridColumn) {
+	if index < 0 {
+		panic("SetColumn in grid out of range")
+	}
+	for len(g.columnSizing) <= index {
+		g.columnSizing = append(g.columnSizing, WeightedColumn(1))
+	}
+	g.columnSizing[index] = column
+	NeedLayout(g)
+}
+
+// Column returns the sizing of the column with the index.
+func (g Grid) Column(index int) GridColumn {
+	if index < 0 || index >= len(g.columnSizing) {
+		return WeightedColumn(1)
+	}
+	return g.columnSizing[index]
+}
+
+// SetGaps sets the space between the rows and between the columns.
+func (g *Grid) SetGaps(rowGap, columnGap int) {
+	g.rowGap = rowGap
+	g.columnGap = columnGap
+	NeedLayout(g)
+}
+
+// Gaps returns the space between the rows and between the columns.
+func (g Grid) Gaps() (rowGap, columnGap int) {
+	return g.rowGap, g.columnGap
+}
+
 func (g *Grid) Put(child Control, left, top int) {
 	if (left < 0) || (top < 0) || (left >= g.NumberOfColumns()) || (top >= g.NumberOfRows()) {
 		panic("Put in grid out of range")
@@ -121,7 +260,7 @@ func (g *Grid) Put(child Control, left, top int) {
 	mesh := g.getMesh(left, top)
 	if mesh == nil {
 		// New mesh
-		mesh = &Mesh{left: left, top: top, span: 1}
+		mesh = &Mesh{left: left, top: top, span: 1, rowSpan: 1}
 		g.meshes = append(g.meshes, mesh)
 	} else {
 		// Already exists
@@ -181,8 +320,8 @@ func (g *Grid) AppendMesh(mesh *Mesh) {
 		g.columns = mesh.left + mesh.span
 	}
 
-	if mesh.top+1 > g.rows {
-		g.rows = mesh.top + 1
+	if mesh.top+mesh.rows() > g.rows {
+		g.rows = mesh.top + mesh.rows()
 	}
 
 	g.putMesh(mesh)
@@ -243,50 +382,133 @@ func (g *Grid) LayoutWidget(width, height int) {
 	}
 
 	var (
-		y           = 0
-		columnWidth = (width - margin*2) / g.columns
-		rowHeight   = (height - margin*2) / g.rows
+		availableWidth  = width - margin*2 - g.columnGap*(g.columns-1)
+		availableHeight = height - margin*2
+		rowHeight       = (availableHeight - g.rowGap*(g.rows-1)) / g.rows
+		widths          = g.columnWidths(availableWidth, rowHeight)
+		heights         = make([]int, g.rows)
 	)
 
-	g.width = (width - margin*2)
-	g.height = margin * 2
-
-	for row := 0; row < g.rows; row++ {
-		highest := 0
-		for col := 0; col < g.columns; col++ {
-			mesh := g.getMesh(col, row)
-			if mesh == nil || mesh.Control == nil || mesh.Control.Hidden() {
-				continue
-			}
-			// X position is fixed by the columns.
-			x := col * columnWidth
-			maxWidth := columnWidth * mesh.span
-			maxHeight := rowHeight
-			mesh.Control.LayoutWidget(maxWidth, maxHeight)
-			// Stretch row if widget overflows it.
-			_, oh := mesh.Control.WidgetOverflow()
-			if oh > 0 {
-				maxHeight := height
-				mesh.Control.LayoutWidget(maxWidth, maxHeight)
-			}
-
-			mesh.MoveStyleAligned(x, y, maxWidth, maxHeight)
-
-			ww, wh := mesh.Control.WidgetSize()
-			if wh > highest {
-				highest = wh
-			}
-			x += ww
+	for _, mesh := range g.meshes {
+		if mesh.Control == nil || mesh.Control.Hidden() {
+			continue
+		}
+		maxWidth := gridSpan(widths, mesh.left, mesh.span, g.columnGap)
+		maxHeight := rowHeight * mesh.rows()
+		mesh.Control.LayoutWidget(maxWidth, maxHeight)
+		// Stretch row if widget overflows it.
+		_, oh := mesh.Control.WidgetOverflow()
+		if oh > 0 {
+			mesh.Control.LayoutWidget(maxWidth, height)
+		}
+		if mesh.rows() == 1 {
+			_, wh := mesh.Control.WidgetSize()
+			heights[mesh.top] = max(heights[mesh.top], wh)
+		}
+	}
+
+	// Widgets that span rows make the last row higher if they don't fit.
+	for _, mesh := range g.meshes {
+		if mesh.Control == nil || mesh.Control.Hidden() || mesh.rows() == 1 {
+			continue
+		}
+		_, wh := mesh.Control.WidgetSize()
+		last := min(mesh.top+mesh.rows(), g.rows) - 1
+		if need := wh - gridSpan(heights, mesh.top, mesh.rows(), g.rowGap); need > 0 {
+			heights[last] += need
 		}
-		// Y position is shifted by the highest in the row, which
-		// must be smaller than rowHeight unless spanned
-		y += highest
-		g.height += highest
 	}
+
+	for _, mesh := range g.meshes {
+		if mesh.Control == nil || mesh.Control.Hidden() {
+			continue
+		}
+		x := gridOffset(widths, mesh.left, g.columnGap)
+		y := gridOffset(heights, mesh.top, g.rowGap)
+		mesh.place(x, y,
+			gridSpan(widths, mesh.left, mesh.span, g.columnGap),
+			gridSpan(heights, mesh.top, mesh.rows(), g.rowGap))
+	}
+
+	// The grid takes the available width, and the height of its rows.
+	if layoutBounded(availableWidth) {
+		g.width = width - margin*2
+	} else {
+		g.width = gridOffset(widths, g.columns, g.columnGap) - g.columnGap
+	}
+	g.height = margin*2 + gridOffset(heights, g.rows, g.rowGap) - g.rowGap
+	g.mirrorChildren(g.width)
 	g.BasicContainer.UpdateOrdered()
 	g.ClipTo(width, height)
 }
 
+// columnWidths returns the widths of the columns for the available width.
+func (g *Grid) columnWidths(availableWidth, rowHeight int) []int {
+	widths := make([]int, g.columns)
+	rest := availableWidth
+	weight := 0.0
+	for col := range widths {
+		sizing := g.Column(col)
+		switch sizing.Sizing {
+		case GridFixed:
+			widths[col] = sizing.Width
+		case GridAuto:
+			widths[col] = g.contentWidth(col, availableWidth, rowHeight)
+		default:
+			weight += sizing.Weight
+			continue
+		}
+		rest -= widths[col]
+	}
+	for col := range widths {
+		sizing := g.Column(col)
+		if sizing.Sizing != GridWeighted {
+			continue
+		}
+		if layoutBounded(availableWidth) && weight > 0 {
+			widths[col] = max(0, int(float64(rest)*sizing.Weight/weight))
+		} else {
+			widths[col] = g.contentWidth(col, availableWidth, rowHeight)
+		}
+	}
+	return widths
+}
+
+// contentWidth returns the width of the widest widget in the column, not
+// counting widgets that span multiple columns.
+func (g *Grid) contentWidth(col, availableWidth, rowHeight int) int {
+	widest := 0
+	for _, mesh := range g.meshes {
+		if mesh.left != col || mesh.span != 1 || mesh.Control == nil || mesh.Control.Hidden() {
+			continue
+		}
+		mesh.Control.LayoutWidget(availableWidth, rowHeight*mesh.rows())
+		ww, _ := mesh.Control.WidgetSize()
+		widest = max(widest, ww)
+	}
+	return widest
+}
+
+// gridOffset returns the offset of the column or row at index, given the
+// sizes of the columns or rows and the gap between them.
+func gridOffset(sizes []int, index, gap int) int {
+	offset := 0
+	for i := 0; i < index && i < len(sizes); i++ {
+		offset += sizes[i] + gap
+	}
+	return offset
+}
+
+// gridSpan returns the size of span columns or rows from index, including
+// the gaps between them.
+func gridSpan(sizes []int, index, span, gap int) int {
+	end := min(index+span, len(sizes))
+	if end <= index {
+		return 0
+	}
+	return gridOffset(sizes, end, gap) - gridOffset(sizes, index, gap) - gap
+}
+
 func (b Grid) DrawWidget(g *Graphic) {
 	dx, dy := b.WidgetAbsolute()
 
@@ -0,0 +1,77 @@
+package ui
+
+import "testing"
+
+func TestGridClassic(t *testing.T) {
+	grid := NewGrid()
+	first, second := newLayoutRecorder(10, 10), newLayoutRecorder(10, 20)
+	grid.Append(first, 0, 0, 1, StyleAlignLeft)
+	grid.Append(second, 1, 1, 1, StyleAlignRight)
+	grid.LayoutWidget(200, 100)
+
+	margin := grid.Style().Margin.Int()
+	columnWidth := (200 - margin*2) / 2
+	if x, y := first.WidgetAt(); x != 0 || y != 0 {
+		t.Errorf("first at %d, %d, want 0, 0", x, y)
+	}
+	if x, y := second.WidgetAt(); x != columnWidth*2-10 || y != 10 {
+		t.Errorf("second at %d, %d, want %d, 10", x, y, columnWidth*2-10)
+	}
+	if w, h := grid.WidgetSize(); w != 200-margin*2 || h != margin*2+30 {
+		t.Errorf("size %dx%d, want %dx%d", w, h, 200-margin*2, margin*2+30)
+	}
+}
+
+func TestGridGaps(t *testing.T) {
+	grid := NewGrid()
+	first, second := newLayoutRecorder(10, 10), newLayoutRecorder(10, 20)
+	grid.Append(first, 0, 0, 1, StyleAlignLeft)
+	grid.Append(second, 1, 1, 1, StyleAlignLeft)
+	grid.SetGaps(4, 8)
+	grid.LayoutWidget(200, 100)
+
+	margin := grid.Style().Margin.Int()
+	columnWidth := (200 - margin*2 - 8) / 2
+	if x, y := first.WidgetAt(); x != 0 || y != 0 {
+		t.Errorf("first at %d, %d, want 0, 0", x, y)
+	}
+	if x, y := second.WidgetAt(); x != columnWidth+8 || y != 10+4 {
+		t.Errorf("second at %d, %d, want %d, %d", x, y, columnWidth+8, 10+4)
+	}
+	if w, h := grid.WidgetSize(); w != 200-margin*2 || h != margin*2+30+4 {
+		t.Errorf("size %dx%d, want %dx%d", w, h, 200-margin*2, margin*2+30+4)
+	}
+}
+
+func TestGridDefaultColumns(t *testing.T) {
+	layout := func(configure func(grid *Grid)) [2][2]int {
+		grid := NewGrid()
+		first, second := newLayoutRecorder(10, 10), newLayoutRecorder(10, 20)
+		grid.Append(first, 0, 0, 1, StyleAlignLeft)
+		grid.Append(second, 1, 1, 1, StyleAlignMiddle)
+		configure(grid)
+		grid.LayoutWidget(200, 100)
+		var at [2][2]int
+		at[0][0], at[0][1] = first.WidgetAt()
+		at[1][0], at[1][1] = second.WidgetAt()
+		return at
+	}
+	classic := layout(func(grid *Grid) {})
+	tests := []struct {
+		name      string
+		configure func(grid *Grid)
+	}{
+		{"weighted columns", func(grid *Grid) {
+			grid.SetColumn(0, WeightedColumn(1))
+			grid.SetColumn(1, WeightedColumn(1))
+		}},
+		{"zero gaps", func(grid *Grid) { grid.SetGaps(0, 0) }},
+		{"vertical align", func(grid *Grid) { grid.SetVerticalAlign(0, 0, StyleAlignStart) }},
+		{"one row", func(grid *Grid) { grid.MergeRows(0, 0, 1) }},
+	}
+	for _, tt := range tests {
+		if at := layout(tt.configure); at != classic {
+			t.Errorf("%s: widgets at %v, want %v", tt.name, at, classic)
+		}
+	}
+}
@@ -73,6 +73,11 @@ func (w *Group) SetChild(child Control) {
 	w.LayoutWidget(w.width, w.height)
 }
 
+// Child returns the child widget of the group.
+func (w *Group) Child() Control {
+	return w.child
+}
+
 func (w *Group) Destroy() {
 	// first hide ourselves
 	w.Hide()
@@ -90,6 +95,7 @@ func (w Group) Borderless() bool {
 
 func (w *Group) SetBorderless(borderless bool) {
 	w.borderless = borderless
+	Invalidate(w)
 }
 
 func (w *Group) DrawWidget(screen *Graphic) {
@@ -0,0 +1,88 @@
+package ui
+
+import "sync"
+
+// InputSource is a source of input events for a Window. Every tick, the
+// Window sends an UpdateEvent to its widgets, and then the events that
+// InputEvents returns. Events without an origin get the window as origin.
+//
+// By default a Window polls the ebiten input. Use Window.SetInputSource to
+// drive the window with scripted, network-fed or recorded events in stead.
+type InputSource interface {
+	InputEvents(w *Window) []Event
+}
+
+// InputSourceFunc is a function that can be used as an InputSource.
+type InputSourceFunc func(w *Window) []Event
+
+// InputEvents implements InputSource.
+func (f InputSourceFunc) InputEvents(w *Window) []Event {
+	return f(w)
+}
+
+// EventQueue is an InputSource that returns the events that were pushed
+// to it since the previous tick. It is safe to push events from other
+// goroutines, for example from a network connection.
+type EventQueue struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+// NewEventQueue returns a new empty event queue.
+func NewEventQueue() *EventQueue {
+	return &EventQueue{}
+}
+
+// Push adds events to the queue.
+func (q *EventQueue) Push(events ...Event) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.events = append(q.events, events...)
+}
+
+// InputEvents implements InputSource. It empties the queue.
+func (q *EventQueue) InputEvents(w *Window) []Event {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	events := q.events
+	q.events = nil
+	return events
+}
+
+// MultiInput returns an InputSource that returns the events of all sources,
+// in order. For example, MultiInput(w.LiveInput(), queue) lets both the user
+// and a remote control use the window.
+func MultiInput(sources ...InputSource) InputSource {
+	return InputSourceFunc(func(w *Window) []Event {
+		var events []Event
+		for _, source := range sources {
+			events = append(events, source.InputEvents(w)...)
+		}
+		return events
+	})
+}
+
+// replaySource is the InputSource that Window.StartReplay uses.
+type replaySource struct {
+	replayer *EventReplayer
+	start    int64
+	previous InputSource
+}
+
+func (r *replaySource) InputEvents(w *Window) []Event {
+	var events []Event
+	r.replayer.Replay(w.ticks-r.start, w, func(e Event) {
+		events = append(events, e)
+	})
+	if r.replayer.Done() {
+		dprintln("replay: done", r.replayer.Err())
+		if w.input == r {
+			w.input = r.previous
+		}
+	}
+	return events
+}
+
+var _ InputSource = &inputState{}
+var _ InputSource = &EventQueue{}
+var _ InputSource = &replaySource{}
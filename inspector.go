@@ -0,0 +1,489 @@
+package ui
+
+import "fmt"
+import "os"
+import "golang.org/x/exp/slices"
+import "strconv"
+import "strings"
+
+// DefaultInspectorAccelerator is the key combination that toggles the
+// inspector if the EBUI_INSPECTOR environment variable is set to 1.
+var DefaultInspectorAccelerator = Accelerator{Key: KeyI, EventModifiers: EventModifiers{Control: true, Shift: true}}
+
+// Inspector is a Pane that shows the live widget tree of a Window, and the
+// position, size, overflow, layer, place in the focus chain, focus path and
+// style of the selected widget. The style of the selected widget can be edited live.
+//
+// While the inspector is open, the widget under the mouse is highlighted.
+// Alt+click selects the widget under the mouse.
+type Inspector struct {
+	*Pane
+	window   *Window
+	tree     *inspectorTree
+	info     *Label
+	margin   *Entry
+	width    *Entry
+	height   *Entry
+	color    *Entry
+	fill     *Entry
+	message  *Label
+	hovered  Control
+	selected Control
+	active   bool // active is true if the inspector gets the keyboard events.
+}
+
+const inspectorWidth = 360
+const inspectorTreeLines = 16
+
+func newInspector(w *Window) *Inspector {
+	in := &Inspector{window: w}
+	in.tree = &inspectorTree{inspector: in}
+	in.tree.SetStyle(theme.Entry)
+	in.info = NewLabel("")
+	in.margin = in.newField()
+	in.width = in.newField()
+	in.height = in.newField()
+	in.color = in.newField()
+	in.fill = in.newField()
+	in.message = NewLabel("")
+
+	box := NewVerticalBox()
+	box.Append(in.tree)
+	box.Append(in.info)
+	for _, field := range []struct {
+		name  string
+		entry *Entry
+	}{
+		{"margin", in.margin}, {"width", in.width}, {"height", in.height},
+		{"color", in.color}, {"fill", in.fill},
+	} {
+		tray := NewTray()
+		tray.Append(NewLabel(field.name))
+		tray.Append(field.entry)
+		box.Append(tray)
+	}
+	apply := NewButton("Apply style")
+	apply.OnClicked(func(*Button) {
+		in.applyStyle()
+	})
+	box.Append(apply)
+	box.Append(in.message)
+
+	in.Pane = NewPane("Inspector", inspectorWidth, w.height, false)
+	in.Pane.SetPreserved(true)
+	in.Pane.SetChild(box)
+	in.Pane.SetParent(w)
+	return in
+}
+
+func (in *Inspector) newField() *Entry {
+	e := NewEntry()
+	e.OnChanged(func(*Entry) {
+		in.message.SetText("")
+	})
+	return e
+}
+
+// Selected returns the selected widget, if any.
+func (in *Inspector) Selected() Control {
+	return in.selected
+}
+
+// Select selects the widget c and shows its properties.
+func (in *Inspector) Select(c Control) {
+	in.selected = c
+	in.tree.reveal(c)
+	in.loadStyle()
+	in.refresh()
+}
+
+// loadStyle fills in the style fields with the style of the selected widget.
+func (in *Inspector) loadStyle() {
+	in.message.SetText("")
+	if in.selected == nil {
+		for _, e := range []*Entry{in.margin, in.width, in.height, in.color, in.fill} {
+			e.SetText("")
+		}
+		return
+	}
+	style := in.selected.Style()
+	in.margin.SetText(strconv.Itoa(style.Margin.Int()))
+	in.width.SetText(strconv.Itoa(style.Size.Width.Int()))
+	in.height.SetText(strconv.Itoa(style.Size.Height.Int()))
+	in.color.SetText(style.Color.String())
+	in.fill.SetText(style.Fill.Color.String())
+}
+
+// applyStyle sets the edited style on the selected widget and lays it out
+// again.
+func (in *Inspector) applyStyle() {
+	if in.selected == nil {
+		return
+	}
+	style := in.selected.Style()
+	var err error
+	parseSize := func(e *Entry, size *StyleSize) {
+		if err != nil {
+			return
+		}
+		var i int
+		if i, err = strconv.Atoi(strings.TrimSpace(e.Text())); err == nil {
+			*size = StyleSize(i)
+		}
+	}
+	parseColor := func(e *Entry, col *StyleColor) {
+		if err != nil {
+			return
+		}
+		err = col.UnmarshalText([]byte(strings.TrimSpace(e.Text())))
+	}
+	parseSize(in.margin, &style.Margin)
+	parseSize(in.width, &style.Size.Width)
+	parseSize(in.height, &style.Size.Height)
+	parseColor(in.color, &style.Color)
+	parseColor(in.fill, &style.Fill.Color)
+	if err != nil {
+		in.message.SetText(err.Error())
+		return
+	}
+	in.selected.SetStyle(&style)
+	NeedLayout(in.selected)
+	in.message.SetText("style applied")
+	in.refresh()
+}
+
+// refresh updates the properties of the selected widget.
+func (in *Inspector) refresh() {
+	in.info.SetText(in.describe(in.selected))
+	in.layout()
+}
+
+func (in *Inspector) layout() {
+	x, y := in.WidgetAt()
+	in.Pane.LayoutWidget(inspectorWidth, in.window.height)
+	in.MoveWidget(x, y)
+}
+
+func (in *Inspector) describe(c Control) string {
+	if c == nil {
+		return "nothing selected"
+	}
+	var sb strings.Builder
+	x, y := c.WidgetAt()
+	ax, ay := ControlAbsolute(c)
+	w, h := c.WidgetSize()
+	ow, oh := c.WidgetOverflow()
+	fmt.Fprintf(&sb, "%s\n", inspectorName(c))
+	fmt.Fprintf(&sb, "at: %d, %d  absolute: %d, %d\n", x, y, ax, ay)
+	fmt.Fprintf(&sb, "size: %d x %d  overflow: %d x %d\n", w, h, ow, oh)
+	fmt.Fprintf(&sb, "layer: %d  hidden: %t  enabled: %t\n", c.WidgetLayer(), c.Hidden(), c.Enabled())
+
+	chain := FocusChain(in.window)
+	place := "not in it"
+	if index := slices.Index(chain, c); index >= 0 {
+		place = fmt.Sprintf("%d of %d", index+1, len(chain))
+	}
+	fmt.Fprintf(&sb, "focus chain: %s  focusable: %t\n", place, ControlFocusable(c))
+
+	var path []string
+	for _, f := range focusPath(in.window) {
+		name := controlTypeName(f)
+		if f == c {
+			name = "[" + name + "]"
+		}
+		path = append(path, name)
+	}
+	fmt.Fprintf(&sb, "focus path: %s\n", strings.Join(path, " > "))
+
+	style := c.Style()
+	fmt.Fprintf(&sb, "style: margin %d, size %d x %d, align %q\n",
+		style.Margin.Int(), style.Size.Width.Int(), style.Size.Height.Int(), style.Align.String())
+	fmt.Fprintf(&sb, "  color %s, fill %s %s\n", style.Color.String(), style.Fill.Color.String(), style.Fill.Sprite.String())
+	fmt.Fprintf(&sb, "  font %s %d", style.Font.Family, style.Font.Size.Int())
+	return sb.String()
+}
+
+// focusPath returns the focused widgets from the window down to the
+// innermost focused widget.
+func focusPath(w *Window) []Control {
+	var path []Control
+	for c := FocusedControl(w); c != nil; c = c.Parent() {
+		path = append([]Control{c}, path...)
+	}
+	return path
+}
+
+// controlTypeName returns the name of the type of c, such as "Button".
+func controlTypeName(c Control) string {
+	name := fmt.Sprintf("%T", c)
+	name = strings.TrimPrefix(name, "*")
+	return strings.TrimPrefix(name, "ui.")
+}
+
+// inspectorName returns the type name and the name of c.
+func inspectorName(c Control) string {
+	name := controlTypeName(c)
+	if text := accessibilityName(c); text != "" {
+		if runes := []rune(text); len(runes) > 24 {
+			text = string(runes[:24]) + "…"
+		}
+		name += " " + strconv.Quote(strings.ReplaceAll(text, "\n", " "))
+	}
+	return name
+}
+
+// ControlAt returns the innermost visible widget below c, c included,
+// that contains the absolute position x, y, or nil if there is none.
+// The widgets that are drawn last are found first.
+func ControlAt(c Control, x, y int) Control {
+	if c == nil || c.Hidden() {
+		return nil
+	}
+	children := accessibilityChildren(c)
+	for i := len(children) - 1; i >= 0; i-- {
+		if found := ControlAt(children[i], x, y); found != nil {
+			return found
+		}
+	}
+	cx, cy := ControlAbsolute(c)
+	cw, ch := c.WidgetSize()
+	if InsideBounds(cx, cy, cw, ch, x, y) {
+		return c
+	}
+	return nil
+}
+
+// handle handles the event for the inspector.
+// It returns true if the event was used.
+func (in *Inspector) handle(e Event) bool {
+	if in.Hidden() {
+		return false
+	}
+
+	switch ev := e.(type) {
+	case *UpdateEvent:
+		// Only build the tree again if widgets were laid out, which is also
+		// the case after widgets were added or removed.
+		if in.tree.stale {
+			in.tree.update()
+		}
+		// Only lay out the info again if the description changed.
+		if in.selected != nil {
+			if info := in.describe(in.selected); info != in.info.Text() {
+				in.info.SetText(info)
+			}
+		}
+		in.Pane.HandleWidget(e)
+		return false
+	case *MouseMoveEvent:
+		in.Pane.HandleWidget(e)
+		if ev.Inside(in.Pane) {
+			in.hovered = in.tree.controlAt(ev.X, ev.Y)
+			return true
+		}
+		in.hovered = ControlAt(in.window, ev.X, ev.Y)
+		return false
+	case *MouseClickEvent:
+		if ev.Inside(in.Pane) {
+			in.active = true
+			in.Pane.HandleWidget(e)
+			return true
+		}
+		in.active = false
+		if ev.Modifiers().Alt {
+			in.Select(ControlAt(in.window, ev.X, ev.Y))
+			return true
+		}
+		return false
+	case *MouseReleaseEvent, *WheelEvent:
+		if EventInside(e, in.Pane) || in.Pane.dragging || in.Pane.resizing {
+			in.Pane.HandleWidget(e)
+			return true
+		}
+		return false
+	case *KeyPressEvent, *KeyReleaseEvent, *CharEvent:
+		if in.active {
+			in.Pane.HandleWidget(e)
+			return true
+		}
+	}
+	return false
+}
+
+// draw draws the highlights and the inspector.
+func (in *Inspector) draw(screen *Graphic) {
+	if in.Hidden() {
+		return
+	}
+	if in.selected != nil && !in.selected.Hidden() {
+		x, y := ControlAbsolute(in.selected)
+		w, h := in.selected.WidgetSize()
+		StrokeRect(screen, x, y, w, h, 2, theme.Active.Fill.Color.RGBA())
+	}
+	if in.hovered != nil && in.hovered != in.selected {
+		x, y := ControlAbsolute(in.hovered)
+		w, h := in.hovered.WidgetSize()
+		StrokeRect(screen, x, y, w, h, 1, theme.Focus.Color.RGBA())
+		TextDraw(screen, inspectorName(in.hovered), textFaceDebug, x, y-2, textColorDebug)
+	}
+	in.Pane.DrawWidget(screen)
+}
+
+// inspectorLine is a line of the widget tree in the inspector.
+type inspectorLine struct {
+	control Control
+	depth   int
+}
+
+// inspectorTree shows the widget tree of the window in the inspector.
+type inspectorTree struct {
+	BasicWidget
+	inspector *Inspector
+	lines     []inspectorLine
+	from      int
+	stale     bool // stale is set if the window was laid out since the update.
+}
+
+func (t *inspectorTree) update() {
+	t.stale = false
+	t.lines = t.lines[:0]
+	var walk func(c Control, depth int)
+	walk = func(c Control, depth int) {
+		t.lines = append(t.lines, inspectorLine{control: c, depth: depth})
+		for _, child := range accessibilityChildren(c) {
+			if child != nil {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(t.inspector.window, 0)
+	t.from = max(0, min(t.from, len(t.lines)-inspectorTreeLines))
+}
+
+// reveal scrolls the tree so the line of c is shown.
+func (t *inspectorTree) reveal(c Control) {
+	t.update()
+	for i, line := range t.lines {
+		if line.control == c {
+			if i < t.from || i >= t.from+inspectorTreeLines {
+				t.from = max(0, i-inspectorTreeLines/2)
+			}
+			return
+		}
+	}
+}
+
+func (t *inspectorTree) lineHeight() int {
+	return t.Style().Font.Face.Metrics().Height.Round()
+}
+
+// controlAt returns the control of the line at the absolute x, y.
+func (t *inspectorTree) controlAt(x, y int) Control {
+	tx, ty := t.WidgetAbsolute()
+	if !InsideBounds(tx, ty, t.width, t.height, x, y) {
+		return nil
+	}
+	index := t.from + (y-ty-t.Style().Margin.Int())/t.lineHeight()
+	if index < 0 || index >= len(t.lines) {
+		return nil
+	}
+	return t.lines[index].control
+}
+
+func (t *inspectorTree) LayoutWidget(width, height int) {
+	margin := t.Style().Margin.Int()
+	t.width = inspectorWidth - 4*margin
+	t.height = inspectorTreeLines*t.lineHeight() + 2*margin
+	t.ClipTo(width, height)
+}
+
+func (t *inspectorTree) DrawWidget(screen *Graphic) {
+	dx, dy := t.WidgetAbsolute()
+	style := t.Style()
+	margin := style.Margin.Int()
+	lh := t.lineHeight()
+	FillFrameStyle(screen, dx, dy, t.width, t.height, style)
+	clip := GraphicClip(screen, dx, dy, t.width, t.height)
+	face := style.Font.Face
+	col := style.Color.RGBA()
+
+	y := dy + margin
+	for i := t.from; i < len(t.lines) && i < t.from+inspectorTreeLines; i++ {
+		line := t.lines[i]
+		if line.control == t.inspector.selected {
+			FillRect(clip, dx, y, t.width, lh, theme.Active.Fill.Color.RGBA())
+		} else if line.control == t.inspector.hovered {
+			StrokeRect(clip, dx, y, t.width, lh, 1, theme.Focus.Color.RGBA())
+		}
+		text := strings.Repeat("  ", line.depth) + inspectorName(line.control)
+		lineCol := col
+		if line.control.Hidden() {
+			lineCol = theme.Disable.Color.RGBA()
+		}
+		TextDrawOffset(clip, text, face, dx+margin, y, lineCol)
+		y += lh
+	}
+}
+
+func (t *inspectorTree) HandleWidget(ev Event) {
+	switch e := ev.(type) {
+	case *MouseClickEvent:
+		if c := t.controlAt(e.X, e.Y); c != nil {
+			t.inspector.Select(c)
+		}
+	case *WheelEvent:
+		if e.WheelY > 0 {
+			t.from = max(0, t.from-1)
+		} else if e.WheelY < 0 {
+			t.from = max(0, min(t.from+1, len(t.lines)-inspectorTreeLines))
+		}
+	}
+}
+
+// ToggleInspector shows the inspector of the window, or hides it if it is
+// shown.
+func (w *Window) ToggleInspector() {
+	if w.inspector == nil {
+		w.inspector = newInspector(w)
+		w.inspector.MoveWidget(max(0, w.width-inspectorWidth), 0)
+		w.inspector.Select(nil)
+		return
+	}
+	if w.inspector.Hidden() {
+		w.inspector.closed = false
+		w.inspector.Show()
+		w.inspector.tree.update()
+		w.inspector.refresh()
+	} else {
+		w.inspector.Hide()
+		w.inspector.hovered = nil
+		w.inspector.active = false
+	}
+}
+
+// inspectLayout tells the inspector, if any, that widgets were laid out, so
+// it builds its tree again on the next update.
+func (w *Window) inspectLayout() {
+	if w.inspector != nil {
+		w.inspector.tree.stale = true
+	}
+}
+
+// Inspector returns the inspector of the window, or nil if it was never
+// shown.
+func (w *Window) Inspector() *Inspector {
+	return w.inspector
+}
+
+// EnableInspector lets the accelerator toggle the inspector of the window.
+func (w *Window) EnableInspector(acc Accelerator) {
+	w.AddAccelerator(acc, w.ToggleInspector)
+}
+
+// enableInspectorFromEnv enables the inspector with the default accelerator
+// if the EBUI_INSPECTOR environment variable is 1.
+func (w *Window) enableInspectorFromEnv() {
+	if v, ok := os.LookupEnv("EBUI_INSPECTOR"); ok && v == "1" {
+		w.EnableInspector(DefaultInspectorAccelerator)
+	}
+}
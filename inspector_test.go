@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInspectorName(t *testing.T) {
+	tests := []struct {
+		control Control
+		want    string
+	}{
+		{NewLabel(""), "Label"},
+		{NewLabel("short"), `Label "short"`},
+		{NewLabel("two\nlines"), `Label "two lines"`},
+		{NewButton(strings.Repeat("a", 30)), `Button "` + strings.Repeat("a", 24) + `…"`},
+		{NewLabel(strings.Repeat("é", 30)), `Label "` + strings.Repeat("é", 24) + `…"`},
+		{NewLabel(strings.Repeat("日本", 15)), `Label "` + strings.Repeat("日本", 12) + `…"`},
+	}
+	for _, tt := range tests {
+		if got := inspectorName(tt.control); got != tt.want {
+			t.Errorf("inspectorName = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestInspectorDescribeFocus(t *testing.T) {
+	w := NewWindow("test", 640, 480, false)
+	box := NewVerticalBox()
+	button, entry, label := NewButton("button"), NewEntry(), NewLabel("label")
+	box.Append(button)
+	box.Append(entry)
+	box.Append(label)
+	w.SetChild(box)
+	w.LayoutWidget(640, 480)
+	FocusControl(entry)
+	w.ToggleInspector()
+	in := w.Inspector()
+
+	tests := []struct {
+		control Control
+		want    []string
+	}{
+		{entry, []string{"focus chain: 2 of 2  focusable: true", "focus path: ", "> [Entry]"}},
+		{button, []string{"focus chain: 1 of 2  focusable: true"}},
+		{label, []string{"focus chain: not in it  focusable: false"}},
+	}
+	for _, tt := range tests {
+		info := in.describe(tt.control)
+		for _, want := range tt.want {
+			if !strings.Contains(info, want) {
+				t.Errorf("%s: %q not in\n%s", controlTypeName(tt.control), want, info)
+			}
+		}
+	}
+}
+
+func TestInspectorTreeUpdate(t *testing.T) {
+	w := NewWindow("test", 640, 480, false)
+	box := NewVerticalBox()
+	box.Append(NewLabel("label"))
+	w.SetChild(box)
+	w.LayoutWidget(640, 480)
+	w.ToggleInspector()
+	in := w.Inspector()
+	update := &UpdateEvent{BasicEvent: BasicEvent{EventOrigin: w}}
+	in.handle(update)
+	lines := len(in.tree.lines)
+
+	box.Append(NewLabel("added"))
+	in.handle(update)
+	if len(in.tree.lines) != lines {
+		t.Errorf("tree built again before the layout")
+	}
+	w.Layout(640, 480)
+	in.handle(update)
+	if len(in.tree.lines) != lines+1 {
+		t.Errorf("%d lines after the layout, want %d", len(in.tree.lines), lines+1)
+	}
+}
@@ -37,6 +37,7 @@ func (e *Journal) SetText(text string) {
 	for i, line := range lines {
 		e.lines[i] = []rune(line)
 	}
+	NeedLayout(e)
 }
 
 func (e *Journal) Append(text string) {
@@ -49,6 +50,7 @@ func (e *Journal) Append(text string) {
 	for _, line := range lines {
 		e.lines = append(e.lines, []rune(line))
 	}
+	NeedLayout(e)
 }
 
 func (e *Journal) Text() string {
@@ -1,12 +1,12 @@
 package ui
 
-import "github.com/hajimehoshi/ebiten/v2/text"
 import "golang.org/x/image/font"
 import "strings"
 
 type Label struct {
 	BasicWidget
-	text string
+	text    string
+	binding widgetBinding
 }
 
 type labelKind int
@@ -26,7 +26,11 @@ func (l *Label) Text() string {
 }
 
 func (l *Label) SetText(text string) {
+	if l.text == text {
+		return
+	}
 	l.text = text
+	NeedLayout(l)
 }
 
 func oneLineTextSize(face Face, text string) (width, height int) {
@@ -79,7 +83,12 @@ func (l Label) DrawWidget(dst *Graphic) {
 	dx += widgetMargin
 	dy += widgetMargin + textFace.Metrics().Ascent.Round()
 
-	text.Draw(dst, l.text, textFace, dx, dy, textColor)
+	if l.RightToLeft() {
+		align := l.Style().Align.Directed(true)
+		TextDrawAligned(dst, l.text, textFace, dx, dy, l.width-widgetMargin*2, align, textColor)
+	} else {
+		TextDraw(dst, l.text, textFace, dx, dy, textColor)
+	}
 	l.DrawDebug(dst, "LAB")
 }
 
@@ -1,9 +1,98 @@
 package ui
 
+// Layout lays out the children of a container. It lays out each visible
+// child within the available width and height, moves it relative to the
+// container, starting from 0, 0, and returns the size the children take
+// together. The container adds its margins around that.
 type Layout interface {
-	Layout(parent Control, controls ...Control)
+	Layout(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int)
 }
 
-func VerticalLayout(parent Control, children ...Control) {
+// LayoutFunc is a function that implements Layout.
+type LayoutFunc func(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int)
 
+func (f LayoutFunc) Layout(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int) {
+	return f(parent, width, height, children...)
+}
+
+// VerticalLayout places the children the one below the other, like a Box.
+func VerticalLayout(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int) {
+	for _, child := range children {
+		if child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(width, height)
+		child.MoveWidget(0, layoutHeight)
+		childWidth, childHeight := child.WidgetSize()
+		layoutHeight += childHeight
+		layoutWidth = max(layoutWidth, childWidth)
+	}
+	return layoutWidth, layoutHeight
+}
+
+// HorizontalLayout places the children the one next to the other, like a
+// Tray.
+func HorizontalLayout(parent Control, width, height int, children ...Control) (layoutWidth, layoutHeight int) {
+	for _, child := range children {
+		if child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(width, height)
+		child.MoveWidget(layoutWidth, 0)
+		childWidth, childHeight := child.WidgetSize()
+		layoutWidth += childWidth
+		layoutHeight = max(layoutHeight, childHeight)
+	}
+	return layoutWidth, layoutHeight
+}
+
+var _ Layout = LayoutFunc(VerticalLayout)
+var _ Layout = LayoutFunc(HorizontalLayout)
+
+// layoutBounded returns whether size is a real limit, and not a size that
+// was derived from LayoutUnlimited.
+func layoutBounded(size int) bool {
+	return size > 0 && size < LayoutUnlimited/2
+}
+
+// widgetResizer is implemented by the widgets that embed BasicWidget. It
+// lets a layout stretch a widget beyond the size it laid itself out with.
+type widgetResizer interface {
+	resizeWidget(width, height int)
+}
+
+func (w *BasicWidget) resizeWidget(width, height int) {
+	w.width, w.height = width, height
+}
+
+// sizeWidget lays c out in width by height, and makes it exactly that size
+// if it can be resized, even if it laid itself out smaller or larger.
+func sizeWidget(c Control, width, height int) {
+	c.LayoutWidget(max(1, width), max(1, height))
+	resizer, ok := c.(widgetResizer)
+	if !ok {
+		return
+	}
+	if cw, ch := c.WidgetSize(); cw != width || ch != height {
+		resizer.resizeWidget(width, height)
+	}
+}
+
+// stretchWidget makes c at least width by height, if it can be resized. It
+// lays c out again in the stretched size, so a container places its
+// children in all of it.
+func stretchWidget(c Control, width, height int) {
+	resizer, ok := c.(widgetResizer)
+	if !ok {
+		return
+	}
+	cw, ch := c.WidgetSize()
+	if cw >= width && ch >= height {
+		return
+	}
+	width, height = max(cw, width), max(ch, height)
+	c.LayoutWidget(width, height)
+	if cw, ch = c.WidgetSize(); cw < width || ch < height {
+		resizer.resizeWidget(max(cw, width), max(ch, height))
+	}
 }
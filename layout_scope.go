@@ -0,0 +1,125 @@
+package ui
+
+// layoutScope tracks the layout of a single widget, so it can be laid out
+// again on its own, without laying out the whole window.
+type layoutScope struct {
+	dirty       bool // dirty is set while the widget is queued for layout.
+	constrained bool // constrained is set once the widget was laid out.
+	width       int  // width is the available width of the last layout.
+	height      int  // height is the available height of the last layout.
+}
+
+// constrain stores the available size of a layout. It is called by ClipTo,
+// which the widgets call at the end of LayoutWidget.
+func (s *layoutScope) constrain(width, height int) {
+	s.constrained = true
+	s.width, s.height = width, height
+}
+
+// layoutScoper is implemented by the widgets that embed BasicWidget.
+type layoutScoper interface {
+	layoutScope() *layoutScope
+}
+
+func (w *BasicWidget) layoutScope() *layoutScope {
+	return &w.scope
+}
+
+// layoutScopeOf returns the layout scope of c, or nil if it has none.
+func layoutScopeOf(c Control) *layoutScope {
+	if scoper, ok := c.(layoutScoper); ok {
+		return scoper.layoutScope()
+	}
+	return nil
+}
+
+// outerControl returns the widget that embeds c. Due to static inheritance,
+// an embedded BasicContainer may call NeedLayout on itself, but then the
+// LayoutWidget of the widget that embeds it must be called in stead. That
+// widget is found among the children of the parent, as it shares the layout
+// scope with c.
+func outerControl(c Control) Control {
+	scope := layoutScopeOf(c)
+	parent := c.Parent()
+	if scope == nil || parent == nil {
+		return c
+	}
+	for _, child := range ControlChildren(parent) {
+		if child != nil && layoutScopeOf(child) == scope {
+			return child
+		}
+	}
+	return c
+}
+
+// needLayoutOf queues c to be laid out again before the next frame.
+func (w *Window) needLayoutOf(c Control) {
+	c = outerControl(c)
+	if c == Control(w) {
+		w.Relayout()
+		return
+	}
+	scope := layoutScopeOf(c)
+	if scope == nil {
+		w.Relayout()
+		return
+	}
+	if !scope.dirty {
+		scope.dirty = true
+		w.layoutQueue = append(w.layoutQueue, c)
+	}
+}
+
+// layoutQueued lays out the queued widgets again. If the whole window must be
+// laid out anyway, this is done in stead.
+func (w *Window) layoutQueued() {
+	queue := w.layoutQueue
+	w.layoutQueue = nil
+	w.inspectLayout()
+	for _, c := range queue {
+		if w.needLayout {
+			break
+		}
+		if scope := layoutScopeOf(c); scope.dirty {
+			w.relayout(c)
+		}
+	}
+	w.clearLayoutQueue(queue)
+	if w.needLayout {
+		w.LayoutWidget(w.width, w.height)
+	}
+}
+
+// relayout lays out c again with the available size of its last layout. If
+// its size changed, its parent is laid out as well, and so on. If the
+// window is reached, the whole window must be laid out again.
+func (w *Window) relayout(c Control) {
+	for ; c != nil; c = c.Parent() {
+		c = outerControl(c)
+		if c == Control(w) {
+			w.Relayout()
+			return
+		}
+		scope := layoutScopeOf(c)
+		if scope == nil || !scope.constrained {
+			// Never laid out, so the parent must lay it out.
+			continue
+		}
+		scope.dirty = false
+		oldWidth, oldHeight := c.WidgetSize()
+		c.LayoutWidget(scope.width, scope.height)
+		if width, height := c.WidgetSize(); width == oldWidth && height == oldHeight {
+			Invalidate(c)
+			return
+		}
+	}
+}
+
+// clearLayoutQueue clears the dirty flag of the queued widgets.
+func (w *Window) clearLayoutQueue(queue []Control) {
+	for _, c := range queue {
+		if scope := layoutScopeOf(c); scope != nil {
+			scope.dirty = false
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	TestInit()
+	os.Exit(m.Run())
+}
+
+// layoutRecorder is a widget of a fixed size that records the sizes it was
+// laid out in.
+type layoutRecorder struct {
+	BasicWidget
+	size    [2]int
+	layouts [][2]int
+}
+
+func newLayoutRecorder(width, height int) *layoutRecorder {
+	return &layoutRecorder{size: [2]int{width, height}}
+}
+
+func (r *layoutRecorder) LayoutWidget(width, height int) {
+	r.layouts = append(r.layouts, [2]int{width, height})
+	r.width, r.height = r.size[0], r.size[1]
+}
+
+func TestStretchWidget(t *testing.T) {
+	tests := []struct {
+		width, height int
+		want          [2]int
+		layout        bool
+	}{
+		{0, 0, [2]int{10, 20}, false},
+		{5, 5, [2]int{10, 20}, false},
+		{30, 0, [2]int{30, 20}, true},
+		{0, 40, [2]int{10, 40}, true},
+		{30, 40, [2]int{30, 40}, true},
+	}
+	for _, tt := range tests {
+		r := newLayoutRecorder(10, 20)
+		r.LayoutWidget(LayoutUnlimited, LayoutUnlimited)
+		stretchWidget(r, tt.width, tt.height)
+		if w, h := r.WidgetSize(); w != tt.want[0] || h != tt.want[1] {
+			t.Errorf("stretchWidget(%d, %d): size %dx%d, want %v",
+				tt.width, tt.height, w, h, tt.want)
+		}
+		last := r.layouts[len(r.layouts)-1]
+		if laidOut := len(r.layouts) > 1; laidOut != tt.layout || (laidOut && last != tt.want) {
+			t.Errorf("stretchWidget(%d, %d): layouts %v, want a layout in %v: %v",
+				tt.width, tt.height, r.layouts, tt.want, tt.layout)
+		}
+	}
+}
+
+func TestStretchContainer(t *testing.T) {
+	dock := NewDock()
+	top, bottom := newLayoutRecorder(10, 10), newLayoutRecorder(10, 10)
+	dock.Append(top, DockTop)
+	dock.Append(bottom, DockBottom)
+	dock.LayoutWidget(LayoutUnlimited, LayoutUnlimited)
+	stretchWidget(dock, 50, 100)
+	margin := dock.Style().Margin.Int()
+	if x, y := bottom.WidgetAt(); x != margin || y != 90-margin {
+		t.Errorf("bottom at %d, %d, want %d, %d", x, y, margin, 90-margin)
+	}
+	if w, _ := top.WidgetSize(); w != 50-margin*2 {
+		t.Errorf("top width %d, want %d", w, 50-margin*2)
+	}
+}
@@ -153,6 +153,8 @@ func (m *MPEG1Media) AudioCallback(_ *mpeg.MPEG, msamples *mpeg.Samples) {
 	}
 }
 
+// SetVideoCallback sets the callback for decoded video frames. The callback
+// is called on the decoding goroutine, use Do to change widgets from it.
 func (m *MPEG1Media) SetVideoCallback(callback func(Media, *image.RGBA)) {
 	if m.HasVideo() {
 		m.MPEG.SetVideoCallback(func(_ *mpeg.MPEG, frame *mpeg.Frame) {
@@ -165,6 +167,8 @@ func (m *MPEG1Media) SetVideoCallback(callback func(Media, *image.RGBA)) {
 	}
 }
 
+// SetAudioCallback sets the callback for decoded audio samples. The callback
+// is called on the decoding goroutine, use Do to change widgets from it.
 func (m *MPEG1Media) SetAudioCallback(callback func(Media, *Samples)) {
 	if m.HasAudio() {
 		m.MPEG.SetAudioCallback(func(_ *mpeg.MPEG, msamples *mpeg.Samples) {
@@ -273,6 +277,7 @@ func (p *MediaPlayer) getVideo() {
 	case image := <-p.Media.Video():
 		if image.RGBA != nil {
 			p.img.WritePixels(image.RGBA.Pix)
+			Invalidate(p)
 		}
 	default:
 		return
@@ -410,6 +415,7 @@ func NewMediaPlayer(title string, media Media) *MediaPlayer {
 
 func (w *MediaPlayer) SetTitle(title string) {
 	w.title = title
+	Invalidate(w)
 }
 
 func (w *MediaPlayer) SetMedia(m Media) {
@@ -465,6 +471,7 @@ func (w *MediaPlayer) Borderless() bool {
 
 func (w *MediaPlayer) SetBorderless(borderless bool) {
 	w.borderless = borderless
+	Invalidate(w)
 }
 
 func (w *MediaPlayer) DrawWidget(screen *Graphic) {
@@ -71,6 +71,11 @@ func (m *Menu) Title() string {
 	return m.Text()
 }
 
+// Parts returns the box with the menu items.
+func (m *Menu) Parts() []Control {
+	return []Control{&m.box}
+}
+
 func (m *Menu) LayoutWidget(width, height int) {
 	margin := m.Style().Margin.Int()
 	h := m.Style().Size.Height.Int()
@@ -141,16 +146,27 @@ type MenuItem struct {
 	id        int
 	checked   bool
 	disabled  bool
+	// accelerator is the keyboard shortcut of the item, if any.
+	accelerator *Accelerator
+}
+
+// checkboxSize returns the size of the checkbox of a checked menu item.
+// It is used both for the layout and for drawing so they agree.
+func (i *MenuItem) checkboxSize() (int, int) {
+	return theme.Checkbox.Size.Width.Int(), theme.Checkbox.Size.Height.Int()
 }
 
 func (i *MenuItem) LayoutWidget(width, height int) {
 	// A menu bar has a fixed height
 	margin := i.Style().Margin.Int()
-	checkboxWidth := theme.Checkbox.Size.Width.Int()
-	checkboxHeight := theme.Checkbox.Size.Height.Int()
+	checkboxWidth, checkboxHeight := i.checkboxSize()
 
 	i.TextWidget.LayoutWidget(width-2*margin, height-2*margin)
 	w, h := i.TextWidget.WidgetSize()
+	if i.accelerator != nil {
+		aw, _ := oneLineTextSize(i.Style().Font.Face, i.accelerator.String())
+		w += aw + menuAcceleratorGap
+	}
 	if i.kind == menuItemChecked {
 		w += checkboxWidth + margin
 		if h < checkboxHeight {
@@ -164,6 +180,32 @@ func (i *MenuItem) LayoutWidget(width, height int) {
 	i.ClipTo(width, height)
 }
 
+// menuAcceleratorGap is the minimal distance between the title of a menu
+// item and its accelerator.
+const menuAcceleratorGap = 24
+
+// SetAccelerator sets the keyboard shortcut of the item. The window activates
+// the item when the accelerator is pressed, unless the item is disabled.
+// The accelerator is shown right aligned in the menu item.
+func (i *MenuItem) SetAccelerator(acc Accelerator) {
+	i.accelerator = &acc
+	NeedLayout(i)
+}
+
+// RemoveAccelerator removes the keyboard shortcut of the item.
+func (i *MenuItem) RemoveAccelerator() {
+	i.accelerator = nil
+	NeedLayout(i)
+}
+
+// Accelerator returns the keyboard shortcut of the item, and whether it has one.
+func (i *MenuItem) Accelerator() (Accelerator, bool) {
+	if i.accelerator == nil {
+		return Accelerator{}, false
+	}
+	return *i.accelerator, true
+}
+
 func (m *Menu) AppendItem(title string) *MenuItem {
 	item := newMenuItem(menuItemNormal, title, m)
 	m.items = append(m.items, item)
@@ -247,13 +289,27 @@ func (i MenuItem) DrawWidget(dst *Graphic) {
 	margin := i.Style().Margin.Int()
 
 	i.TextWidget.DrawWidget(dst)
+	if i.accelerator != nil && i.kind != menuItemSeparator {
+		face := i.Style().Font.Face
+		col := i.Style().Color.RGBA()
+		if !i.Enabled() {
+			col = theme.Disable.Color.RGBA()
+		}
+		str := i.accelerator.String()
+		aw, _ := oneLineTextSize(face, str)
+		ax := dx + i.width - margin - aw
+		if i.kind == menuItemChecked {
+			checkboxWidth, _ := i.checkboxSize()
+			ax -= checkboxWidth + margin
+		}
+		TextDrawOffset(dst, str, face, ax, dy, col)
+	}
 	if i.kind == menuItemSeparator {
 		uiAtlas.DrawSprite(dst, dx, dy+i.height/2, i.width, 3, "hsep")
 	} else if i.kind == menuItemChecked {
 
 		cbStyle := theme.Checkbox
-		checkboxWidth := cbStyle.Size.Width.Int()
-		checkboxHeight := cbStyle.Size.Height.Int()
+		checkboxWidth, checkboxHeight := i.checkboxSize()
 		checkSprite := theme.Icons.Check.String()
 
 		dx += (i.width - checkboxWidth - margin)
@@ -265,23 +321,30 @@ func (i MenuItem) DrawWidget(dst *Graphic) {
 	}
 }
 
+// activate activates the item as if it was clicked.
+func (i *MenuItem) activate() {
+	if i.kind == menuItemChecked {
+		i.checked = !i.checked
+		if i.onClicked != nil {
+			i.onClicked(i)
+		}
+	} else if i.kind == menuItemNormal {
+		if i.onClicked != nil {
+			i.onClicked(i)
+		}
+		if i.menu != nil {
+			i.menu.closeMenu()
+		}
+	}
+}
+
 func (i *MenuItem) HandleWidget(ev Event) {
 	if mc, ok := ev.(*MouseClickEvent); ok {
 		if mc.Inside(i) {
 			if !i.Enabled() {
 				// do nothing.
-			} else if i.kind == menuItemChecked {
-				i.checked = !i.checked
-				if i.onClicked != nil {
-					i.onClicked(i)
-				}
-			} else if i.kind == menuItemNormal {
-				if i.onClicked != nil {
-					i.onClicked(i)
-				}
-				if i.menu != nil {
-					i.menu.closeMenu()
-				}
+			} else {
+				i.activate()
 			}
 		}
 	}
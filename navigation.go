@@ -0,0 +1,436 @@
+package ui
+
+import "cmp"
+import "math"
+
+import "golang.org/x/exp/slices"
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// Focusable is implemented by widgets that can tell whether they can get the
+// focus by keyboard or gamepad navigation. BasicWidget implements it.
+type Focusable interface {
+	Focusable() bool
+}
+
+// HasParts is implemented by widgets that consist of several parts that are
+// not all in their children, such as Tab, which has a tab bar and the pages.
+type HasParts interface {
+	Parts() []Control
+}
+
+// ControlChildren returns the widgets that are directly below c in the widget
+// tree. It uses the parts of c, its children, or its child, in that order.
+func ControlChildren(c Control) []Control {
+	if hp, ok := c.(HasParts); ok {
+		return hp.Parts()
+	}
+	if hc, ok := c.(HasChildren); ok {
+		return hc.Children()
+	}
+	if hc, ok := c.(HasChild); ok {
+		if child := hc.Child(); child != nil {
+			return []Control{child}
+		}
+	}
+	return nil
+}
+
+// TabOrderer is implemented by widgets that have a tab order.
+// BasicWidget implements it.
+type TabOrderer interface {
+	TabOrder() int
+}
+
+// ControlFocusable returns true if c can get the focus by navigation.
+func ControlFocusable(c Control) bool {
+	if f, ok := c.(Focusable); ok {
+		return f.Focusable()
+	}
+	return false
+}
+
+// FocusableControls returns the visible and enabled controls below c that
+// can get the focus, in tree order. The controls below hidden or disabled
+// widgets are skipped as well.
+func FocusableControls(c Control) []Control {
+	var result []Control
+	var walk func(c Control)
+	walk = func(c Control) {
+		for _, child := range ControlChildren(c) {
+			if child == nil || child.Hidden() || !child.Enabled() {
+				continue
+			}
+			if ControlFocusable(child) {
+				result = append(result, child)
+			}
+			walk(child)
+		}
+	}
+	walk(c)
+	return result
+}
+
+// FocusChain returns the controls below c that can get the focus, in the
+// order in which Tab moves the focus through them. The controls with a
+// positive tab order come first, the others follow in tree order.
+func FocusChain(c Control) []Control {
+	chain := FocusableControls(c)
+	slices.SortStableFunc(chain, func(a, b Control) int {
+		return cmp.Compare(tabOrderKey(a), tabOrderKey(b))
+	})
+	return chain
+}
+
+func tabOrderKey(c Control) int {
+	if to, ok := c.(TabOrderer); ok && to.TabOrder() > 0 {
+		return to.TabOrder()
+	}
+	return math.MaxInt
+}
+
+// FocusControl gives the focus to c, by setting the focus of all the
+// parents of c up to the window, so the focused events reach c.
+// The controls that lose the focus receive an AwayEvent, and c receives
+// a FocusEvent.
+func FocusControl(c Control) {
+	be := &BasicEvent{EventOrigin: c}
+	child := c
+	for parent := c.Parent(); parent != nil; parent = parent.Parent() {
+		if _, ok := parent.(*Window); ok {
+			break
+		}
+		if hc, ok := parent.(HasChildren); ok {
+			SetNewFocus(be, parent, child, hc.Children()...)
+		} else {
+			parent.SetFocus(child)
+		}
+		child = parent
+	}
+	c.HandleWidget(&FocusEvent{BasicEvent: *be, Focused: c})
+}
+
+// Direction is a direction for spatial focus navigation.
+type Direction int
+
+const (
+	DirectionUp Direction = iota
+	DirectionDown
+	DirectionLeft
+	DirectionRight
+)
+
+// navigationScope returns the widget in which navigation moves the focus:
+// the top dialog if there is one, otherwise the child of the window.
+func (w *Window) navigationScope() Control {
+	if w.dialogs != nil {
+		ordered := w.dialogs.Ordered()
+		for i := len(ordered) - 1; i >= 0; i-- {
+			dialog := ordered[i]
+			if pane, ok := dialog.(*Pane); ok && pane.closed {
+				continue
+			}
+			if !dialog.Hidden() {
+				return dialog
+			}
+		}
+	}
+	return w.child
+}
+
+// topPane returns the top open pane of the dialogs, or nil if there is none.
+func (w *Window) topPane() *Pane {
+	if pane, ok := w.navigationScope().(*Pane); ok && w.dialogs != nil && pane.Parent() == Control(w.dialogs) {
+		return pane
+	}
+	return nil
+}
+
+// Navigate moves the focus to the nearest focusable widget in the given
+// direction from the currently focused widget. If no widget has the focus,
+// the top left focusable widget gets it. It returns the newly focused
+// widget, or nil if the focus did not move.
+func (w *Window) Navigate(dir Direction) Control {
+	scope := w.navigationScope()
+	if scope == nil {
+		return nil
+	}
+	candidates := FocusableControls(scope)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	current := FocusedControl(w)
+	if !ControlFocusable(current) {
+		current = nil
+	}
+
+	var best Control
+	bestScore := math.MaxFloat64
+	if current == nil {
+		// Start at the top left.
+		for _, candidate := range candidates {
+			x, y := ControlAbsolute(candidate)
+			score := float64(y)*2 + float64(x)
+			if score < bestScore {
+				best, bestScore = candidate, score
+			}
+		}
+	} else {
+		cx, cy := navigationCenter(current)
+		for _, candidate := range candidates {
+			if candidate == current {
+				continue
+			}
+			x, y := navigationCenter(candidate)
+			primary, secondary := x-cx, y-cy
+			switch dir {
+			case DirectionUp:
+				primary, secondary = cy-y, x-cx
+			case DirectionDown:
+				primary, secondary = y-cy, x-cx
+			case DirectionLeft:
+				primary = cx - x
+			}
+			if primary <= 0 {
+				continue
+			}
+			// Prefer widgets that are in line with the current one.
+			score := primary + 2*math.Abs(secondary)
+			if score < bestScore {
+				best, bestScore = candidate, score
+			}
+		}
+	}
+
+	if best != nil {
+		FocusControl(best)
+	}
+	return best
+}
+
+// FocusNext moves the focus to the next widget in the focus chain of the
+// window, or of the top dialog if there is one. After the last widget it
+// wraps around to the first one. It returns the newly focused widget.
+func (w *Window) FocusNext() Control {
+	return w.focusStep(1)
+}
+
+// FocusPrevious moves the focus to the previous widget in the focus chain,
+// like FocusNext, but backwards.
+func (w *Window) FocusPrevious() Control {
+	return w.focusStep(-1)
+}
+
+func (w *Window) focusStep(step int) Control {
+	scope := w.navigationScope()
+	if scope == nil {
+		return nil
+	}
+	chain := FocusChain(scope)
+	if len(chain) == 0 {
+		return nil
+	}
+	index := slices.Index(chain, FocusedControl(w))
+	if index < 0 {
+		if step > 0 {
+			index = 0
+		} else {
+			index = len(chain) - 1
+		}
+	} else {
+		index = (index + step + len(chain)) % len(chain)
+	}
+	FocusControl(chain[index])
+	return chain[index]
+}
+
+// TabHandler is implemented by widgets that use the Tab key themselves, such
+// as a Note, which inserts a tab. If the focused widget wants the Tab key
+// press, the window sends it to the widget in stead of moving the focus.
+type TabHandler interface {
+	WantsTab(ke *KeyPressEvent) bool
+}
+
+// handleTab moves the focus with Tab and Shift+Tab, unless the focused widget
+// wants the Tab key. It returns true if the event was used, which is only the
+// case if the focus moved, and for the release of such a Tab key.
+func (w *Window) handleTab(e Event) bool {
+	switch ke := e.(type) {
+	case *KeyPressEvent:
+		if ke.Key != KeyTab {
+			return false
+		}
+		w.tabMoved = false
+		before := FocusedControl(w)
+		if handler, ok := before.(TabHandler); ok && handler.WantsTab(ke) {
+			return false
+		}
+		var after Control
+		if ke.Modifiers().Shift {
+			after = w.FocusPrevious()
+		} else {
+			after = w.FocusNext()
+		}
+		w.tabMoved = after != nil && after != before
+		return w.tabMoved
+	case *KeyReleaseEvent:
+		if ke.Key != KeyTab {
+			return false
+		}
+		moved := w.tabMoved
+		w.tabMoved = false
+		return moved
+	}
+	return false
+}
+
+func navigationCenter(c Control) (x, y float64) {
+	ax, ay := ControlAbsolute(c)
+	w, h := c.WidgetSize()
+	return float64(ax) + float64(w)/2, float64(ay) + float64(h)/2
+}
+
+// Activate activates the focused widget as if it was clicked in the center.
+func (w *Window) Activate() {
+	focused := FocusedControl(w)
+	if focused == nil || !ControlFocusable(focused) {
+		return
+	}
+	x, y := navigationCenter(focused)
+	basic := BasicEvent{EventOrigin: w}
+	me := MouseEvent{BasicEvent: basic, X: int(x), Y: int(y)}
+	w.HandleWidget(&MouseClickEvent{MouseEvent: me, Button: MouseButtonLeft})
+	w.HandleWidget(&MouseReleaseEvent{MouseEvent: me, Button: MouseButtonLeft})
+}
+
+// Cancel closes the top dialog pane if there is one, otherwise it sends an
+// escape key press to the focused widget.
+func (w *Window) Cancel() {
+	if pane := w.topPane(); pane != nil {
+		pane.closePaneWithCallback()
+		w.dialogs.CleanupClosePanes()
+		return
+	}
+	w.sendKey(KeyEscape)
+}
+
+// sendKey sends a key press and release of the key.
+func (w *Window) sendKey(key Key) {
+	ke := KeyEvent{BasicEvent: BasicEvent{EventOrigin: w}, Key: key}
+	w.HandleWidget(&KeyPressEvent{KeyEvent: ke})
+	w.HandleWidget(&KeyReleaseEvent{KeyEvent: ke})
+}
+
+// gamepadStickThreshold is how far the left stick must be pushed to move
+// the focus.
+const gamepadStickThreshold = 0.5
+
+// gamepadNavigationButton returns the standard button of ge, and whether
+// navigation uses it.
+func gamepadNavigationButton(ge GamepadButtonEvent) (StandardGamepadButton, bool) {
+	button := ge.StandardButton
+	if !ge.Standard {
+		// Assume the first buttons are A and B, like most gamepads.
+		switch ge.Button {
+		case 0:
+			button = ebiten.StandardGamepadButtonRightBottom
+		case 1:
+			button = ebiten.StandardGamepadButtonRightRight
+		default:
+			return button, false
+		}
+	}
+	switch button {
+	case ebiten.StandardGamepadButtonLeftTop, ebiten.StandardGamepadButtonLeftBottom,
+		ebiten.StandardGamepadButtonLeftLeft, ebiten.StandardGamepadButtonLeftRight,
+		ebiten.StandardGamepadButtonRightBottom, ebiten.StandardGamepadButtonRightRight:
+		return button, true
+	}
+	return button, false
+}
+
+// handleGamepad handles the gamepad navigation. The D-pad and the left
+// stick move the focus, A activates the focused widget and B cancels or
+// closes the top dialog. When an overlay such as the list of a Dropdown is
+// open, the D-pad and the buttons are sent to it as arrow keys, Enter and
+// Escape. It returns true if the event was used.
+func (w *Window) handleGamepad(e Event) bool {
+	switch ge := e.(type) {
+	case *GamepadButtonPressEvent:
+		button, ok := gamepadNavigationButton(ge.GamepadButtonEvent)
+		if !ok {
+			return false
+		}
+		overlay := w.overlayActive()
+		switch button {
+		case ebiten.StandardGamepadButtonLeftTop:
+			w.navigateOrKey(overlay, DirectionUp, KeyArrowUp)
+		case ebiten.StandardGamepadButtonLeftBottom:
+			w.navigateOrKey(overlay, DirectionDown, KeyArrowDown)
+		case ebiten.StandardGamepadButtonLeftLeft:
+			w.navigateOrKey(overlay, DirectionLeft, KeyArrowLeft)
+		case ebiten.StandardGamepadButtonLeftRight:
+			w.navigateOrKey(overlay, DirectionRight, KeyArrowRight)
+		case ebiten.StandardGamepadButtonRightBottom:
+			if overlay {
+				w.sendKey(KeyEnter)
+			} else {
+				w.Activate()
+			}
+		case ebiten.StandardGamepadButtonRightRight:
+			if overlay {
+				w.sendKey(KeyEscape)
+			} else {
+				w.Cancel()
+			}
+		}
+		return true
+	case *GamepadButtonReleaseEvent:
+		// The release of a button that navigation used on the press.
+		_, ok := gamepadNavigationButton(ge.GamepadButtonEvent)
+		return ok
+	case *GamepadAxisEvent:
+		axis := ge.StandardAxis
+		if !ge.Standard {
+			if ge.Axis > 1 {
+				return false
+			}
+			axis = StandardGamepadAxis(ge.Axis)
+		}
+		var dir Direction
+		var key Key
+		switch {
+		case axis == ebiten.StandardGamepadAxisLeftStickHorizontal && ge.Value >= gamepadStickThreshold && ge.Previous < gamepadStickThreshold:
+			dir, key = DirectionRight, KeyArrowRight
+		case axis == ebiten.StandardGamepadAxisLeftStickHorizontal && ge.Value <= -gamepadStickThreshold && ge.Previous > -gamepadStickThreshold:
+			dir, key = DirectionLeft, KeyArrowLeft
+		case axis == ebiten.StandardGamepadAxisLeftStickVertical && ge.Value >= gamepadStickThreshold && ge.Previous < gamepadStickThreshold:
+			dir, key = DirectionDown, KeyArrowDown
+		case axis == ebiten.StandardGamepadAxisLeftStickVertical && ge.Value <= -gamepadStickThreshold && ge.Previous > -gamepadStickThreshold:
+			dir, key = DirectionUp, KeyArrowUp
+		default:
+			return false
+		}
+		w.navigateOrKey(w.overlayActive(), dir, key)
+		return true
+	}
+	return false
+}
+
+// overlayActive returns true if the window or the top dialog has an overlay,
+// such as the list of a Dropdown, that gets the events.
+func (w *Window) overlayActive() bool {
+	if pane := w.topPane(); pane != nil {
+		return len(pane.overlays) > 0
+	}
+	return len(w.overlays) > 0
+}
+
+func (w *Window) navigateOrKey(overlay bool, dir Direction, key Key) {
+	if overlay {
+		w.sendKey(key)
+	} else {
+		w.Navigate(dir)
+	}
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+func TestHandleGamepad(t *testing.T) {
+	w := NewWindow("test", 200, 100, false)
+	w.SetChild(NewButton("button"))
+	ge := GamepadEvent{BasicEvent: BasicEvent{EventOrigin: w}, Standard: true}
+	button := func(b StandardGamepadButton) GamepadButtonEvent {
+		return GamepadButtonEvent{GamepadEvent: ge, Button: GamepadButton(b), StandardButton: b}
+	}
+	axis := func(a StandardGamepadAxis, value, previous float64) *GamepadAxisEvent {
+		return &GamepadAxisEvent{GamepadEvent: ge, Axis: int(a), StandardAxis: a, Value: value, Previous: previous}
+	}
+	tests := []struct {
+		name  string
+		event Event
+		used  bool
+	}{
+		{"press down", &GamepadButtonPressEvent{button(ebiten.StandardGamepadButtonLeftBottom)}, true},
+		{"release down", &GamepadButtonReleaseEvent{GamepadButtonEvent: button(ebiten.StandardGamepadButtonLeftBottom)}, true},
+		{"press shoulder", &GamepadButtonPressEvent{button(ebiten.StandardGamepadButtonFrontTopLeft)}, false},
+		{"release shoulder", &GamepadButtonReleaseEvent{GamepadButtonEvent: button(ebiten.StandardGamepadButtonFrontTopLeft)}, false},
+		{"stick right", axis(ebiten.StandardGamepadAxisLeftStickHorizontal, 0.8, 0), true},
+		{"stick held", axis(ebiten.StandardGamepadAxisLeftStickHorizontal, 0.9, 0.8), false},
+		{"stick slightly", axis(ebiten.StandardGamepadAxisLeftStickHorizontal, 0.2, 0), false},
+		{"right stick", axis(ebiten.StandardGamepadAxisRightStickVertical, 0.8, 0), false},
+	}
+	for _, tt := range tests {
+		if used := w.handleGamepad(tt.event); used != tt.used {
+			t.Errorf("%s: used %v, want %v", tt.name, used, tt.used)
+		}
+	}
+}
+
+func TestHandleTab(t *testing.T) {
+	w := NewWindow("test", 200, 100, false)
+	ke := KeyEvent{BasicEvent: BasicEvent{EventOrigin: w}, Key: KeyTab}
+	press := func() bool { return w.handleTab(&KeyPressEvent{KeyEvent: ke}) }
+	release := func() bool { return w.handleTab(&KeyReleaseEvent{KeyEvent: ke}) }
+
+	w.SetChild(NewLabel("label"))
+	if press() || release() {
+		t.Errorf("Tab used without focusable widgets")
+	}
+
+	box := NewVerticalBox()
+	button := NewButton("button")
+	box.Append(button)
+	w.SetChild(box)
+	if !press() || !release() {
+		t.Errorf("Tab not used when it focused the button")
+	}
+	if FocusedControl(w) != Control(button) {
+		t.Errorf("focused %T, want the button", FocusedControl(w))
+	}
+	if press() || release() {
+		t.Errorf("Tab used when the focus did not move")
+	}
+}
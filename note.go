@@ -32,6 +32,8 @@ type Note struct {
 	closeInputState func()
 	lastInputState  TextInputState
 	active          bool
+	binding         widgetBinding
+	Validation
 }
 
 type PasswordNote struct {
@@ -52,6 +54,7 @@ const noteMinWidth = 100.0
 func newNote() *Note {
 	e := &Note{}
 	e.lines = [][]rune{[]rune{}}
+	e.focusable = true
 	e.cursor.X = 0
 	e.cursor.Y = 0
 	// Set placeholder
@@ -153,6 +156,7 @@ func (e Note) DrawWidget(dst *Graphic) {
 		curY += curH + margin
 	}
 
+	e.drawError(dst, dx, dy, e.width, e.height)
 	e.DrawDebug(dst, "NOT")
 }
 
@@ -167,6 +171,7 @@ func (c *Note) ReadOnly() bool {
 
 func (c *Note) SetReadOnly(readonly bool) {
 	c.readonly = readonly
+	Invalidate(c)
 }
 
 func (c *Note) Placeholder() string {
@@ -175,6 +180,7 @@ func (c *Note) Placeholder() string {
 
 func (c *Note) SetPlaceholder(text string) {
 	c.placeholder = text
+	Invalidate(c)
 }
 
 func (n *Note) HandleTextInputState(state TextInputState) {
@@ -186,6 +192,7 @@ func (n *Note) HandleTextInputState(state TextInputState) {
 	} else {
 		n.lastInputState = state
 	}
+	Invalidate(n)
 }
 
 func (n *Note) startTextInput() {
@@ -263,6 +270,7 @@ func (e *Note) insertRunesMultipleLines(runes []rune) {
 }
 
 func (e *Note) HandleWidget(ev Event) {
+	before := e.Text()
 	if _, ok := ev.(*AwayEvent); ok {
 		dprintln("Note.HandleWidget deactivate")
 		e.active = false
@@ -270,7 +278,9 @@ func (e *Note) HandleWidget(ev Event) {
 		return
 	}
 
-	if _, ok := ev.(*MouseClickEvent); ok {
+	_, clicked := ev.(*MouseClickEvent)
+	_, focused := ev.(*FocusEvent)
+	if clicked || focused {
 		dprintln("Note.HandleWidget activate")
 		e.active = true
 		e.startTextInput()
@@ -288,6 +298,8 @@ func (e *Note) HandleWidget(ev Event) {
 		if ke, ok := ev.(*KeyReleaseEvent); ok {
 			e.HandleKeyRelease(ke)
 		}
+		e.binding.changed()
+		e.Validation.changed(before, e.Text())
 	}
 }
 
@@ -312,6 +324,12 @@ func (e *Note) setCursor(x, y int) {
 	e.cursor.Y = y
 }
 
+// WantsTab returns true if the note is editable and Control is not held,
+// so Tab inserts a tab in the note, and Control+Tab moves the focus.
+func (e *Note) WantsTab(kp *KeyPressEvent) bool {
+	return !e.readonly && !kp.Modifiers().Control
+}
+
 func (e *Note) HandleKeyPress(kp *KeyPressEvent) {
 	if e.lastInputState.Text != "" {
 		switch kp.Key {
@@ -363,6 +381,10 @@ func (e *Note) HandleKeyPress(kp *KeyPressEvent) {
 			}
 			e.setCursor(pos, e.cursor.Y-1)
 		}
+	case KeyTab:
+		if e.WantsTab(kp) {
+			e.insertRunes([]rune{'\t'})
+		}
 	case KeyF7:
 		e.startTextInput()
 	case KeyF8:
@@ -14,7 +14,7 @@ type Scrollable interface {
 // Overflow is a widget meant to be used as a wrapper around other
 // widgets, or to be added as a member of other target widgets to help
 // them manage overflow by displaying a Scroller or a Roller if needed,
-// and by providing a sub-bitmap of the screen that will clip the output
+// and by providing a clip of the screen that will clip the output
 // of the target widget.
 //
 // If the target implements the Scrollable interface, then
@@ -24,9 +24,12 @@ type Overflow struct {
 	target    Control
 	roller    *Roller
 	scroller  *Scroller
-	clip      *Graphic
 	maxWidth  int
 	maxHeight int
+	scrollY   int        // scrollY is the current scroll position.
+	rollX     int        // rollX is the current roll position.
+	scrolling *Animation // scrolling animates scrollY.
+	rolling   *Animation // rolling animates rollX.
 }
 
 func NewOverflow(target Control, maxWidth, maxHeight int) *Overflow {
@@ -41,35 +44,57 @@ func NewOverflow(target Control, maxWidth, maxHeight int) *Overflow {
 	o.scroller.SetParent(o)
 	o.roller.SetParent(o)
 	o.target.SetParent(o)
-	o.clip = NewGraphic(o.maxWidth, o.maxHeight)
 
 	o.scroller.OnChanged(func(s *Scroller) {
-		if o.target != nil {
-			y := s.Value()
-			if scrollable, ok := o.target.(Scrollable); ok {
-				scrollable.ScrollWidget(y)
-			} else {
-				x, _ := o.target.WidgetAt()
-				o.target.MoveWidget(x, -y)
-			}
-		}
+		o.scrollTo(s.Value())
 	})
 
 	o.roller.OnChanged(func(s *Roller) {
-		if o.target != nil {
-			x := s.Value()
-			if scrollable, ok := o.target.(Scrollable); ok {
-				scrollable.RollWidget(x)
-			} else {
-				_, y := o.target.WidgetAt()
-				o.target.MoveWidget(-x, y)
-			}
-		}
+		o.rollTo(s.Value())
 	})
 
 	return o
 }
 
+// scrollTo scrolls the target smoothly to y.
+func (o *Overflow) scrollTo(y int) {
+	if o.target == nil {
+		return
+	}
+	stopAnimation(o.scrolling)
+	o.scrolling = AnimateInt(o.scrollY, y, animationShort, EaseOutQuad, func(y int) {
+		o.scrollY = y
+		if scrollable, ok := o.target.(Scrollable); ok {
+			scrollable.ScrollWidget(y)
+		} else {
+			x, _ := o.target.WidgetAt()
+			o.target.MoveWidget(x, -y)
+		}
+	}).Invalidates(o.target).Start()
+}
+
+// rollTo rolls the target smoothly to x.
+func (o *Overflow) rollTo(x int) {
+	if o.target == nil {
+		return
+	}
+	stopAnimation(o.rolling)
+	o.rolling = AnimateInt(o.rollX, x, animationShort, EaseOutQuad, func(x int) {
+		o.rollX = x
+		if scrollable, ok := o.target.(Scrollable); ok {
+			scrollable.RollWidget(x)
+		} else {
+			_, y := o.target.WidgetAt()
+			o.target.MoveWidget(-x, y)
+		}
+	}).Invalidates(o.target).Start()
+}
+
+// Child returns the widget that the overflow scrolls.
+func (o *Overflow) Child() Control {
+	return o.target
+}
+
 func (o Overflow) WidgetSize() (width, height int) {
 	return o.maxWidth, o.maxHeight
 }
@@ -88,13 +113,18 @@ func (o Overflow) LayoutWidget(parentW, parentH int) {
 		o.roller.SetRange(0, targetW-o.width)
 	}
 
+	// From right to left, the scroller is on the left.
 	o.scroller.LayoutWidget(o.width, o.height)
 	w, _ := o.scroller.WidgetSize()
-	o.scroller.MoveWidget(o.width-w, 0)
+	scrollerX, rollerX := o.width-w, 0
+	if o.RightToLeft() {
+		scrollerX, rollerX = 0, w
+	}
+	o.scroller.MoveWidget(scrollerX, 0)
 
 	_, h := o.roller.WidgetSize()
 	o.roller.LayoutWidget(o.width-w, o.height)
-	o.roller.MoveWidget(0, o.height-h)
+	o.roller.MoveWidget(rollerX, o.height-h)
 }
 
 func (o Overflow) DrawWidget(screen *Graphic) {
@@ -102,14 +132,10 @@ func (o Overflow) DrawWidget(screen *Graphic) {
 	// tw, th := o.target.WidgetSize()
 
 	if o.target != nil {
-		wx, wy := o.target.WidgetAt()
-		// Have to temporarily move the widget to origin 0,0
-		// since DrawWidget adds the parent offset, but the clip is a blank
-		// at offset 0, 0 and is positioned afterwards.
-		o.target.MoveWidget(wx-dx, wy-dy)
-		o.target.DrawWidget(o.clip)
-		o.target.MoveWidget(wx, wy)
-		DrawGraphicAt(screen, o.clip, dx, dy)
+		// The clip uses the same coordinates as the screen,
+		// so the target can be drawn at its own position.
+		clip := GraphicClip(screen, dx, dy, o.maxWidth, o.maxHeight)
+		o.target.DrawWidget(clip)
 	}
 
 	// if tw > o.maxWidth {
@@ -19,7 +19,11 @@ type Pane struct {
 	dragging                bool
 	resizing                bool
 	title                   string
-	Ability                 // Ability lets Pane inherit abilities.
+	fade                    float64    // fade is the transparency while the pane opens.
+	slide                   int        // slide is the vertical offset while the pane opens.
+	opening                 *Animation // opening animates fade and slide.
+	offscreen               *Graphic   // offscreen is drawn on while the pane opens.
+	Ability                            // Ability lets Pane inherit abilities.
 
 	BasicOverlayer
 }
@@ -42,11 +46,38 @@ func NewPane(title string, width, height int, hasMenubar bool) *Pane {
 
 func (w *Pane) SetTitle(title string) {
 	w.title = title
+	Invalidate(w)
 }
 
 var paneHeaderHeight = 24
 var paneLine = 1
 
+// The buttons in the header of a pane.
+const (
+	paneButtonClose = iota
+	paneButtonMinimize
+	paneButtonMaximize
+	paneButtons
+)
+
+// buttonX returns the horizontal position of a button in the header. The
+// buttons are on the right, or on the left from right to left.
+func (p *Pane) buttonX(button int) int {
+	if p.RightToLeft() {
+		return paneHeaderHeight * button
+	}
+	return p.width - paneHeaderHeight*(button+1)
+}
+
+// dragX returns the horizontal position of the part of the header that is
+// not covered by the buttons, which drags the pane.
+func (p *Pane) dragX() int {
+	if p.RightToLeft() {
+		return paneHeaderHeight * paneButtons
+	}
+	return 0
+}
+
 func (p *Pane) layoutContents(width, height int) (int, int) {
 	margin := p.Style().Margin.Int()
 	childWidth := width - (2 * margin)
@@ -102,6 +133,11 @@ func (w *Pane) SetChild(child Control) {
 	w.LayoutWidget(w.width, w.height)
 }
 
+// Child returns the child widget of the pane.
+func (w *Pane) Child() Control {
+	return w.child
+}
+
 func (w *Pane) Destroy() {
 	// first hide ourselves
 	w.Hide()
@@ -121,7 +157,54 @@ func (w *Pane) OnClosing(f func(*Pane)) {
 	w.onClosing = f
 }
 
+// paneSlide is the distance that a pane slides down while it opens.
+const paneSlide = 24
+
+// animateOpen fades the pane in and slides it down into place.
+func (w *Pane) animateOpen() {
+	stopAnimation(w.opening)
+	w.opening = NewAnimation(animationMedium, EaseOutCubic, func(t float64) {
+		w.fade = 1 - t
+		w.slide = lerpInt(-paneSlide, 0, t)
+	}).OnDone(func() {
+		if w.offscreen != nil {
+			w.offscreen.Deallocate()
+			w.offscreen = nil
+		}
+	}).Invalidates(w).Start()
+}
+
 func (w *Pane) DrawWidget(screen *Graphic) {
+	if w.opening.Running() {
+		w.drawOpening(screen)
+		return
+	}
+	w.drawPane(screen)
+}
+
+// drawOpening draws the pane offscreen, and then on the screen with the
+// transparency and offset of the opening animation. The software renderer
+// draws the pane straight on the target without the animation.
+func (w *Pane) drawOpening(screen *Graphic) {
+	if softRendererOf(screen) != nil {
+		w.drawPane(screen)
+		return
+	}
+	size := GraphicRenderer(screen).Bounds().Max
+	if w.offscreen == nil || w.offscreen.Bounds().Max != size {
+		if w.offscreen != nil {
+			w.offscreen.Deallocate()
+		}
+		w.offscreen = NewGraphic(size.X, size.Y)
+	} else {
+		w.offscreen.Clear()
+	}
+	w.drawPane(w.offscreen)
+	alpha := byte(255 * (1 - w.fade))
+	GraphicRenderer(screen).DrawGraphic(w.offscreen, 0, w.slide, size.X, size.Y, RGBA{R: alpha, G: alpha, B: alpha, A: alpha})
+}
+
+func (w *Pane) drawPane(screen *Graphic) {
 	dx, dy := w.WidgetAbsolute()
 	var (
 		icons     = theme.Icons
@@ -140,12 +223,13 @@ func (w *Pane) DrawWidget(screen *Graphic) {
 	}
 	if w.title != "" {
 		tw, _ := oneLineTextSize(textFace, w.title)
-		TextDrawOffset(screen, w.title, textFace, dx+w.width/2-tw/2-paneHeaderHeight*3/2, dy, textColor)
+		dragWidth := w.width - paneHeaderHeight*paneButtons
+		TextDrawOffset(screen, w.title, textFace, dx+w.dragX()+dragWidth/2-tw/2, dy, textColor)
 	}
 
-	iconAtlas.DrawSprite(screen, dx+w.width-paneHeaderHeight, dy, paneHeaderHeight, paneHeaderHeight, icons.Close.String())
-	iconAtlas.DrawSprite(screen, dx+w.width-paneHeaderHeight*2, dy, paneHeaderHeight, paneHeaderHeight, icons.Minimize.String())
-	iconAtlas.DrawSprite(screen, dx+w.width-paneHeaderHeight*3, dy, paneHeaderHeight, paneHeaderHeight, icons.Maximize.String())
+	iconAtlas.DrawSprite(screen, dx+w.buttonX(paneButtonClose), dy, paneHeaderHeight, paneHeaderHeight, icons.Close.String())
+	iconAtlas.DrawSprite(screen, dx+w.buttonX(paneButtonMinimize), dy, paneHeaderHeight, paneHeaderHeight, icons.Minimize.String())
+	iconAtlas.DrawSprite(screen, dx+w.buttonX(paneButtonMaximize), dy, paneHeaderHeight, paneHeaderHeight, icons.Maximize.String())
 
 	if w.child != nil && !w.minimized {
 		w.child.DrawWidget(screen)
@@ -212,17 +296,17 @@ func (p *Pane) HandleWidget(ev Event) {
 
 	// Ok, maybe it is a pane maniplation then.
 	if mc, ok := ev.(*MouseClickEvent); ok {
-		if p.MouseInsidePart(p.width-paneHeaderHeight, 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
+		if p.MouseInsidePart(p.buttonX(paneButtonClose), 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
 			// close button
 			dprintln("Pane.HandleWidget: close")
 			p.closePaneWithCallback()
 			SetCursorShape(CursorShapeDefault)
 			return
-		} else if p.MouseInsidePart(p.width-paneHeaderHeight*2, 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
+		} else if p.MouseInsidePart(p.buttonX(paneButtonMinimize), 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
 			// minimize button
 			dprintln("Pane.HandleWidget: minimize")
 			p.minimized = true
-		} else if p.MouseInsidePart(p.width-paneHeaderHeight*3, 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
+		} else if p.MouseInsidePart(p.buttonX(paneButtonMaximize), 0, paneHeaderHeight, paneHeaderHeight, mc.MouseEvent) {
 			if p.minimized {
 				p.minimized = false
 			} else {
@@ -234,7 +318,7 @@ func (p *Pane) HandleWidget(ev Event) {
 			}
 			// maximize button
 			dprintln("Pane.HandleWidget: maximize")
-		} else if p.MouseInsidePart(0, 0, p.width-paneHeaderHeight*3, paneHeaderHeight, mc.MouseEvent) {
+		} else if p.MouseInsidePart(p.dragX(), 0, p.width-paneHeaderHeight*paneButtons, paneHeaderHeight, mc.MouseEvent) {
 			dprintln("Pane.HandleWidget: drag")
 			p.dragging = true
 			SetCursorShape(CursorShapeMove)
@@ -275,7 +359,7 @@ func (p *Pane) HandleWidget(ev Event) {
 		}
 	} else {
 		if mm, ok := ev.(*MouseMoveEvent); ok {
-			if p.MouseInsidePart(0, 0, p.width-paneHeaderHeight*3, paneHeaderHeight, mm.MouseEvent) {
+			if p.MouseInsidePart(p.dragX(), 0, p.width-paneHeaderHeight*paneButtons, paneHeaderHeight, mm.MouseEvent) {
 				SetCursorShape(CursorShapeMove)
 			} else if p.MouseInsidePart(p.width-paneHeaderHeight, p.height-paneHeaderHeight, paneHeaderHeight, paneHeaderHeight, mm.MouseEvent) {
 				SetCursorShape(CursorShapeNWSEResize)
@@ -0,0 +1,21 @@
+package ui
+
+import "testing"
+
+func TestPaneOpeningSoftware(t *testing.T) {
+	SetReducedMotion(false)
+	defer SetReducedMotion(true)
+
+	pane := NewPane("pane", 100, 60, false)
+	pane.LayoutWidget(100, 60)
+	pane.animateOpen()
+	if !pane.opening.Running() {
+		t.Fatalf("the opening animation does not run")
+	}
+	opening := RenderSoftware(pane, 100, 60)
+	pane.opening.Finish()
+	opened := RenderSoftware(pane, 100, 60)
+	if different, _ := CompareImages(opening, opened, 0); different != 0 {
+		t.Errorf("%d pixels differ from the opened pane", different)
+	}
+}
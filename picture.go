@@ -44,23 +44,25 @@ func (w *Picture) SetTitle(title string) {
 func (w *Picture) SetIcon(icon string) {
 	w.icon = icon
 	if w.Image != nil && w.graphic != nil {
-		w.graphic.Dispose()
+		DisposeGraphic(w.graphic)
 		w.graphic = nil
 	}
 	if w.icon != "" {
 		w.graphic = iconAtlas.FindGraphic(w.icon)
 	}
+	Invalidate(w)
 }
 
 func (w *Picture) SetImage(img Image) {
 	w.Image = img
 	if w.graphic != nil && w.icon == "" {
-		w.graphic.Dispose()
+		DisposeGraphic(w.graphic)
 		w.graphic = nil
 	}
 	if w.Image != nil {
 		w.graphic = NewGraphicFromImage(w.Image)
 	}
+	Invalidate(w)
 }
 
 func (w *Picture) Title() string {
@@ -101,7 +103,7 @@ func (w *Picture) Destroy() {
 	if w.icon == "" {
 		// destroy the child graphic if it is not an icon
 		if w.graphic != nil {
-			w.graphic.Dispose()
+			DisposeGraphic(w.graphic)
 		}
 	}
 }
@@ -112,6 +114,7 @@ func (w Picture) Borderless() bool {
 
 func (w *Picture) SetBorderless(borderless bool) {
 	w.borderless = borderless
+	Invalidate(w)
 }
 
 func (w *Picture) DrawWidget(screen *Graphic) {
@@ -0,0 +1,93 @@
+package ui
+
+import "os"
+import "time"
+
+import "github.com/hajimehoshi/ebiten/v2"
+
+// In power saving mode the window is only drawn when something changed, and
+// when nothing happens for a while, the window goes idle and updates at a
+// lower rate. It wakes up again on input, animations, layout, redraws and
+// timers of After and Every that are due before the next idle update.
+
+const (
+	// activeTPS is the update rate of an active window.
+	activeTPS = ebiten.DefaultTPS
+	// idleTPS is the update rate of an idle window in power saving mode.
+	idleTPS = 10
+	// idleAfter is the amount of quiet ticks after which the window goes
+	// idle.
+	idleAfter = activeTPS / 2
+)
+
+// powerSaving is the state of the power saving mode of a window.
+type powerSaving struct {
+	enabled bool
+	idle    bool
+	quiet   int // quiet is the amount of ticks without activity.
+}
+
+// SetPowerSaving enables or disables the power saving mode. In this mode the
+// screen is not cleared every frame, and only drawn again when a redraw was
+// requested, see RequestRedraw. When the window is idle, it is also updated
+// less often, so the CPU can rest. This is useful for applications that are
+// open but not used most of the time. Power saving can also be enabled by
+// setting the environment variable EBUI_POWER_SAVING to 1.
+func (w *Window) SetPowerSaving(enabled bool) {
+	w.powerSaving.enabled = enabled
+	ebiten.SetScreenClearedEveryFrame(!enabled)
+	if !enabled {
+		w.wake()
+	}
+	RequestRedraw()
+}
+
+// PowerSaving returns whether the power saving mode is enabled.
+func (w *Window) PowerSaving() bool {
+	return w.powerSaving.enabled
+}
+
+// Idle returns whether the window is idle in power saving mode.
+func (w *Window) Idle() bool {
+	return w.powerSaving.idle
+}
+
+func (w *Window) enablePowerSavingFromEnv() {
+	if v, ok := os.LookupEnv("EBUI_POWER_SAVING"); ok && v == "1" {
+		w.SetPowerSaving(true)
+	}
+}
+
+// wake makes an idle window update at the full rate again.
+func (w *Window) wake() {
+	w.powerSaving.quiet = 0
+	if w.powerSaving.idle {
+		w.powerSaving.idle = false
+		ebiten.SetTPS(activeTPS)
+	}
+}
+
+// updatePowerSaving is called at the end of each update with whether there
+// was input. It wakes the window up if anything happened, or makes it idle
+// if nothing happened for a while.
+func (w *Window) updatePowerSaving(input bool) {
+	if !w.powerSaving.enabled {
+		return
+	}
+	if input || redrawRequested || w.needLayout || len(w.layoutQueue) > 0 || Animating() || timerDueSoon() {
+		w.wake()
+		return
+	}
+	w.powerSaving.quiet++
+	if w.powerSaving.quiet >= idleAfter && !w.powerSaving.idle {
+		w.powerSaving.idle = true
+		ebiten.SetTPS(idleTPS)
+	}
+}
+
+// timerDueSoon returns whether a timer of After or Every is due before the
+// next idle update, so the window must stay awake to call it on time.
+func timerDueSoon() bool {
+	d, ok := uiScheduler.next()
+	return ok && d < time.Second/idleTPS
+}
@@ -9,6 +9,7 @@ type Radio struct {
 	toggles    []*Toggle
 	selected   int
 	onSelected func(*Radio)
+	binding    widgetBinding
 }
 
 func NewRadio() *Radio {
@@ -35,6 +36,7 @@ func (r *Radio) SetSelected(index int) {
 	if r.onSelected != nil {
 		r.onSelected(r)
 	}
+	r.binding.changed()
 }
 
 func (r *Radio) SetSelectedToggle(toggle *Toggle) {
@@ -106,6 +108,7 @@ func (b *Toggle) Checked() bool {
 
 func (b *Toggle) SetChecked(checked bool) {
 	b.checked = checked
+	Invalidate(b)
 }
 
 func (b *Toggle) OnClicked(f func(*Toggle)) {
@@ -117,6 +120,7 @@ func NewToggle(text string, radio *Radio) *Toggle {
 	b.SetText(text)
 	b.SetChecked(false)
 	b.customStyle = theme.Radio
+	b.focusable = true
 	return b
 }
 
@@ -0,0 +1,164 @@
+package ui
+
+import "bufio"
+import "encoding/json"
+import "fmt"
+import "io"
+import "reflect"
+
+// EventRecord is one line of an event recording. Event recordings are JSON
+// lines files with one EventRecord per input event.
+type EventRecord struct {
+	Tick  int64           `json:"tick"`  // Tick is the tick of the event, counted from the start of the recording.
+	Type  string          `json:"type"`  // Type is the name of the type of the event, such as "MouseClickEvent".
+	Event json.RawMessage `json:"event"` // Event is the event itself.
+}
+
+// eventTypes are the types of events that can be recorded and replayed,
+// by name.
+var eventTypes = map[string]func() Event{}
+
+// RegisterEventType registers an event type for recording and replay.
+// newEvent should return a new pointer to a zero event of that type.
+// The events are encoded with encoding/json.
+func RegisterEventType(newEvent func() Event) {
+	eventTypes[eventTypeName(newEvent())] = newEvent
+}
+
+func eventTypeName(e Event) string {
+	return reflect.TypeOf(e).Elem().Name()
+}
+
+func init() {
+	RegisterEventType(func() Event { return &UpdateEvent{} })
+	RegisterEventType(func() Event { return &MouseMoveEvent{} })
+	RegisterEventType(func() Event { return &MouseClickEvent{} })
+	RegisterEventType(func() Event { return &MouseReleaseEvent{} })
+	RegisterEventType(func() Event { return &WheelEvent{} })
+	RegisterEventType(func() Event { return &KeyPressEvent{} })
+	RegisterEventType(func() Event { return &KeyReleaseEvent{} })
+	RegisterEventType(func() Event { return &CharEvent{} })
+	RegisterEventType(func() Event { return &TouchPressEvent{} })
+	RegisterEventType(func() Event { return &TouchReleaseEvent{} })
+	RegisterEventType(func() Event { return &GamepadConnectEvent{} })
+	RegisterEventType(func() Event { return &GamepadDisconnectEvent{} })
+	RegisterEventType(func() Event { return &GamepadButtonPressEvent{} })
+	RegisterEventType(func() Event { return &GamepadButtonReleaseEvent{} })
+	RegisterEventType(func() Event { return &GamepadAxisEvent{} })
+}
+
+// EventRecorder records input events as JSON lines.
+type EventRecorder struct {
+	enc   *json.Encoder
+	start int64
+	err   error
+}
+
+// NewEventRecorder returns a recorder that writes to wr.
+// The ticks are counted from start.
+func NewEventRecorder(wr io.Writer, start int64) *EventRecorder {
+	return &EventRecorder{enc: json.NewEncoder(wr), start: start}
+}
+
+// Record records the event at the given tick. UpdateEvents and events of types
+// that are not registered are not recorded, since they are not input events.
+// After the first error, nothing is recorded anymore.
+func (r *EventRecorder) Record(tick int64, e Event) error {
+	if r.err != nil {
+		return r.err
+	}
+	if _, ok := e.(*UpdateEvent); ok {
+		return nil
+	}
+	name := eventTypeName(e)
+	if _, ok := eventTypes[name]; !ok {
+		return nil
+	}
+	buf, err := json.Marshal(e)
+	if err != nil {
+		r.err = err
+		return err
+	}
+	r.err = r.enc.Encode(EventRecord{Tick: tick - r.start, Type: name, Event: buf})
+	return r.err
+}
+
+// Err returns the first error that happened while recording.
+func (r *EventRecorder) Err() error {
+	return r.err
+}
+
+// EventReplayer replays a recording of input events made by EventRecorder.
+type EventReplayer struct {
+	scanner *bufio.Scanner
+	next    *EventRecord
+	line    int
+	done    bool
+	err     error
+}
+
+// NewEventReplayer returns a replayer that reads the recording from rd.
+func NewEventReplayer(rd io.Reader) *EventReplayer {
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, 1024*1024)
+	return &EventReplayer{scanner: scanner}
+}
+
+// Done returns true if all events were replayed or if there was an error.
+func (p *EventReplayer) Done() bool {
+	return p.done
+}
+
+// Err returns the error that stopped the replay, if any.
+func (p *EventReplayer) Err() error {
+	return p.err
+}
+
+func (p *EventReplayer) fail(err error) {
+	p.err = fmt.Errorf("replay line %d: %w", p.line, err)
+	p.done = true
+}
+
+// peek returns the next record, or nil if there are no more records.
+func (p *EventReplayer) peek() *EventRecord {
+	for p.next == nil && !p.done {
+		if !p.scanner.Scan() {
+			if err := p.scanner.Err(); err != nil {
+				p.fail(err)
+			}
+			p.done = true
+			return nil
+		}
+		p.line++
+		if len(p.scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &EventRecord{}
+		if err := json.Unmarshal(p.scanner.Bytes(), record); err != nil {
+			p.fail(err)
+			return nil
+		}
+		p.next = record
+	}
+	return p.next
+}
+
+// Replay calls handle for all events up to and including the given tick,
+// counted from the start of the recording. The events originate in origin.
+func (p *EventReplayer) Replay(tick int64, origin Control, handle func(Event)) {
+	for record := p.peek(); record != nil && record.Tick <= tick; record = p.peek() {
+		p.next = nil
+		newEvent, ok := eventTypes[record.Type]
+		if !ok {
+			p.fail(fmt.Errorf("unknown event type %q", record.Type))
+			return
+		}
+		e := newEvent()
+		if err := json.Unmarshal(record.Event, e); err != nil {
+			p.fail(err)
+			return
+		}
+		e.Event().EventOrigin = origin
+		handle(e)
+	}
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// eventLogger is a widget that logs the input events it handles, with the
+// tick of its window.
+type eventLogger struct {
+	BasicWidget
+	window *Window
+	events []loggedEvent
+}
+
+type loggedEvent struct {
+	tick  int64
+	event Event
+}
+
+func (l *eventLogger) LayoutWidget(width, height int) {
+	l.width, l.height = width, height
+}
+
+func (l *eventLogger) HandleWidget(e Event) {
+	if _, ok := e.(*UpdateEvent); ok {
+		return
+	}
+	l.events = append(l.events, loggedEvent{tick: l.window.Ticks(), event: e})
+}
+
+func newLoggedWindow() (*Window, *eventLogger) {
+	w := NewWindow("test", 200, 100, false)
+	logger := &eventLogger{window: w}
+	w.SetChild(logger)
+	w.LayoutWidget(200, 100)
+	return w, logger
+}
+
+func TestRecordReplay(t *testing.T) {
+	me := MouseEvent{X: 20, Y: 30}
+	gamepad := GamepadEvent{ID: 1, Standard: true}
+	script := map[int64][]Event{
+		2: {&KeyPressEvent{KeyEvent: KeyEvent{BasicEvent: BasicEvent{EventModifiers: EventModifiers{Shift: true}}, Key: KeyA}}},
+		3: {&MouseClickEvent{MouseEvent: me, Button: MouseButtonLeft}},
+		5: {&WheelEvent{MouseEvent: me, WheelY: -1.5}},
+		7: {&GamepadAxisEvent{GamepadEvent: gamepad, Axis: 3, StandardAxis: ebiten.StandardGamepadAxisRightStickVertical, Value: 0.75, Previous: 0.25}},
+	}
+	const ticks = 10
+
+	// Record the script fed through an event queue.
+	recording, recorded := newLoggedWindow()
+	queue := NewEventQueue()
+	recording.SetInputSource(queue)
+	var buf bytes.Buffer
+	recording.StartRecording(&buf)
+	start := recording.Ticks()
+	for tick := int64(1); tick <= ticks; tick++ {
+		queue.Push(script[tick]...)
+		recording.Update()
+	}
+	if err := recording.StopRecording(); err != nil {
+		t.Fatalf("recording: %s", err)
+	}
+	if len(recorded.events) != len(script) {
+		t.Fatalf("%d events handled while recording, want %d", len(recorded.events), len(script))
+	}
+
+	// Replay it in another window.
+	replaying, replayed := newLoggedWindow()
+	replayer := replaying.StartReplay(&buf)
+	replayStart := replaying.Ticks()
+	for tick := 0; tick < ticks; tick++ {
+		replaying.Update()
+	}
+	if !replayer.Done() || replayer.Err() != nil {
+		t.Fatalf("replay done %v, error %v", replayer.Done(), replayer.Err())
+	}
+	if len(replayed.events) != len(recorded.events) {
+		t.Fatalf("%d events replayed, want %d", len(replayed.events), len(recorded.events))
+	}
+	for i, want := range recorded.events {
+		got := replayed.events[i]
+		if got.tick-replayStart != want.tick-start {
+			t.Errorf("event %d: tick %d, want %d", i, got.tick-replayStart, want.tick-start)
+		}
+		if got.event.Origin() != Control(replaying) {
+			t.Errorf("event %d: origin %T, want the window", i, got.event.Origin())
+		}
+		// Compare the events without their origin.
+		got.event.Event().EventOrigin = nil
+		want.event.Event().EventOrigin = nil
+		if !reflect.DeepEqual(got.event, want.event) {
+			t.Errorf("event %d: %#v, want %#v", i, got.event, want.event)
+		}
+	}
+}
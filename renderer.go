@@ -0,0 +1,169 @@
+package ui
+
+import "image"
+import "image/color"
+import "sync"
+
+import "github.com/hajimehoshi/ebiten/v2"
+import "github.com/hajimehoshi/ebiten/v2/text"
+
+// The vector package is convenient but unfortunately quite low in performance.
+import "github.com/hajimehoshi/ebiten/v2/vector"
+
+// Renderer draws primitives on a render target. The drawing helpers such as
+// FillRect, TextDraw, FillFrameStyle and DrawSpriteAtScaleColor look up the
+// Renderer of the Graphic they draw on with GraphicRenderer, so widgets can
+// be drawn with ebiten on the GPU or with the software renderer.
+//
+// All coordinates are absolute coordinates on the target.
+type Renderer interface {
+	// Target returns the Graphic that the widgets draw on for this renderer.
+	Target() *Graphic
+	// Bounds returns the drawable area of the target.
+	Bounds() image.Rectangle
+	// Clip returns a renderer that only draws inside the given rectangle.
+	Clip(x, y, w, h int) Renderer
+	// Fill replaces all pixels in the bounds with the color.
+	Fill(col Color)
+	FillRect(x, y, w, h int, col Color)
+	StrokeRect(x, y, w, h, t int, col Color)
+	// StrokeLine strokes a line from x, y to x+w, y+h.
+	StrokeLine(x, y, w, h, t int, col Color)
+	StrokeCircle(cx, cy, r, t int, col Color)
+	FillCircle(cx, cy, r int, col Color)
+	// DrawText draws the text with y as the base line.
+	// Newlines move to the next line.
+	DrawText(str string, face Face, x, y int, col Color)
+	// DrawGraphic draws src scaled to w, h, with its colors multiplied by col.
+	DrawGraphic(src *Graphic, x, y, w, h int, col Color)
+}
+
+// GraphicRenderer returns the Renderer that draws on g.
+// This is the software renderer if g is the target of a SoftRenderer,
+// otherwise it is the ebiten renderer.
+func GraphicRenderer(g *Graphic) Renderer {
+	if s := softRendererOf(g); s != nil {
+		return s
+	}
+	return ebitenRenderer{dst: g}
+}
+
+// ebitenRenderer draws with ebiten on the GPU.
+type ebitenRenderer struct {
+	dst *Graphic
+}
+
+func (e ebitenRenderer) Target() *Graphic {
+	return e.dst
+}
+
+func (e ebitenRenderer) Bounds() image.Rectangle {
+	return e.dst.Bounds()
+}
+
+func (e ebitenRenderer) Clip(x, y, w, h int) Renderer {
+	rect := image.Rect(x, y, x+w, y+h)
+	return ebitenRenderer{dst: e.dst.SubImage(rect).(*ebiten.Image)}
+}
+
+func (e ebitenRenderer) Fill(col Color) {
+	e.dst.Fill(col)
+}
+
+func (e ebitenRenderer) FillRect(x, y, w, h int, col Color) {
+	vector.DrawFilledRect(e.dst, float32(x), float32(y),
+		float32(w), float32(h), col, true)
+}
+
+func (e ebitenRenderer) StrokeRect(x, y, w, h, t int, col Color) {
+	vector.StrokeRect(e.dst, float32(x), float32(y),
+		float32(w), float32(h), float32(t), col, true)
+}
+
+func (e ebitenRenderer) StrokeLine(x, y, w, h, t int, col Color) {
+	vector.StrokeLine(e.dst, float32(x), float32(y),
+		float32(x+w), float32(y+h), float32(t), col, true)
+}
+
+func (e ebitenRenderer) StrokeCircle(cx, cy, r, t int, col Color) {
+	vector.StrokeCircle(e.dst, float32(cx), float32(cy),
+		float32(r), float32(t), col, true)
+}
+
+func (e ebitenRenderer) FillCircle(cx, cy, r int, col Color) {
+	vector.DrawFilledCircle(e.dst, float32(cx), float32(cy),
+		float32(r), col, true)
+}
+
+func (e ebitenRenderer) DrawText(str string, face Face, x, y int, col Color) {
+	text.Draw(e.dst, str, face, x, y, col)
+}
+
+func (e ebitenRenderer) DrawGraphic(src *Graphic, x, y, w, h int, col Color) {
+	opts := ebiten.DrawImageOptions{}
+	sx, sy := src.Size()
+	opts.GeoM.Scale(float64(w)/float64(sx), float64(h)/float64(sy))
+	opts.GeoM.Translate(float64(x), float64(y))
+	r, g, b, a := col.RGBA()
+	rf := float32(r) / float32(0xffff)
+	gf := float32(g) / float32(0xffff)
+	bf := float32(b) / float32(0xffff)
+	af := float32(a) / float32(0xffff)
+	opts.ColorScale.Scale(rf, gf, bf, af)
+	e.dst.DrawImage(src, &opts)
+}
+
+var _ Renderer = ebitenRenderer{}
+
+// graphicSources maps graphics to the image they were made from, so the
+// software renderer can draw them without reading them back from the GPU.
+var graphicSources sync.Map
+
+type subImager interface {
+	SubImage(r image.Rectangle) image.Image
+}
+
+func recordGraphicSource(g *Graphic, img Image) {
+	graphicSources.Store(g, img)
+}
+
+// GraphicSource returns the image that g was made from, or nil if it is not
+// known. For the target of a SoftRenderer this is the drawn image.
+func GraphicSource(g *Graphic) Image {
+	if s := softRendererOf(g); s != nil {
+		return s.Image.SubImage(s.clip)
+	}
+	if img, ok := graphicSources.Load(g); ok {
+		return img.(Image)
+	}
+	return nil
+}
+
+// graphicSize returns the size of g. The size of a target of a software
+// renderer comes from its image, as ebiten panics on the zero image of the
+// target.
+func graphicSize(g *Graphic) (width, height int) {
+	if img := GraphicSource(g); img != nil {
+		b := img.Bounds()
+		return b.Dx(), b.Dy()
+	}
+	return g.Size()
+}
+
+// SubGraphic returns the part of g inside rect. If the source image of g is
+// known, the source of the part is also recorded.
+func SubGraphic(g *Graphic, rect image.Rectangle) *Graphic {
+	sub := g.SubImage(rect).(*ebiten.Image)
+	if src, ok := GraphicSource(g).(subImager); ok {
+		recordGraphicSource(sub, src.SubImage(rect))
+	}
+	return sub
+}
+
+// DisposeGraphic disposes g and forgets its source image.
+func DisposeGraphic(g *Graphic) {
+	graphicSources.Delete(g)
+	g.Dispose()
+}
+
+var colorWhite Color = color.White
@@ -29,6 +29,10 @@ var resources OverlayFS
 
 const defaultFontBoldName = "resource/font/GoNotoCurrent-Bold.ttf"
 const defaultFontName = "resource/font/GoNotoCurrent-Regular.ttf"
+
+// goldenFontName is the font of all text in golden image mode. It is in the
+// repository, so the golden images render the same on every checkout.
+const goldenFontName = "resource/font/IBMPlexMono-Regular.ttf"
 const iconAtlasName = "resource/icon/icon_atlas.json"
 const uiAtlasName = "resource/theme/ui_atlas.json"
 const themeName = "resource/theme/default_theme.json"
@@ -88,7 +92,7 @@ func loadResourceImage(name string) *ebiten.Image {
 	if err != nil {
 		panic(err)
 	}
-	return ebiten.NewImageFromImage(img)
+	return NewGraphicFromImage(img)
 }
 
 func loadResourceJSON[T any](name string) *T {
@@ -113,7 +117,7 @@ func loadResourceFont(name string) *Font {
 
 // returns nil on failure
 func loadResourceFontOptional(name string) *Font {
-	buf := loadResourceBuffer(name)
+	buf := loadResourceBufferOptional(name)
 	if buf == nil {
 		return nil
 	}
@@ -144,8 +148,13 @@ func fontFace(font *Font, size int) Face {
 
 func initResource() {
 	resources = OverlayFS{resource}
-	defaultFontBold = loadResourceFont(defaultFontBoldName)
-	defaultFont = loadResourceFont(defaultFontName)
+	if goldenDir() != "" {
+		defaultFontBold = loadResourceFont(goldenFontName)
+		defaultFont = loadResourceFont(goldenFontName)
+	} else {
+		defaultFontBold = loadResourceFont(defaultFontBoldName)
+		defaultFont = loadResourceFont(defaultFontName)
+	}
 	textFaceDebug = fontFace(defaultFont, textSizeDebug)
 	iconAtlas = loadAtlas(iconAtlasName)
 	uiAtlas = loadAtlas(uiAtlasName)
@@ -8,6 +8,18 @@ type Roller struct {
 	onChanged  func(*Roller)
 }
 
+// SetValue sets the value, limited to the range.
+func (r *Roller) SetValue(value int) {
+	r.RangeValue.SetValue(value)
+	Invalidate(r)
+}
+
+// SetRange sets the range, and limits the value to it.
+func (r *Roller) SetRange(min, max int) {
+	r.RangeValue.SetRange(min, max)
+	Invalidate(r)
+}
+
 func (r *Roller) OnChanged(f func(*Roller)) {
 	r.onChanged = f
 }
@@ -0,0 +1,141 @@
+package ui
+
+import "context"
+import "sync"
+import "time"
+
+// The widgets are not safe for concurrent use. They may only be changed on
+// the UI goroutine, that is, from event handlers and callbacks of widgets.
+// Other goroutines, such as network code or media decoding, should use Do to
+// change widgets.
+//
+// The scheduler is run by the window on each UpdateEvent, before the event
+// is handled by the widgets. The timers of After and Every advance by the
+// duration of the update events, so they also work with the headless
+// uitest.Driver.
+
+type scheduledTimer struct {
+	ctx       context.Context
+	remaining time.Duration
+	every     time.Duration // every is 0 for a one shot timer.
+	f         func()
+}
+
+type scheduler struct {
+	mutex  sync.Mutex
+	queue  []func()
+	timers []*scheduledTimer
+	now    time.Duration // now is the duration of all updates so far.
+}
+
+var uiScheduler scheduler
+
+// uiTime returns the duration of all update events so far. Unlike the wall
+// clock it only advances with the updates, so durations measured with it
+// also work with the headless uitest.Driver.
+func uiTime() time.Duration {
+	uiScheduler.mutex.Lock()
+	defer uiScheduler.mutex.Unlock()
+	return uiScheduler.now
+}
+
+// Do calls f on the UI goroutine, at the start of the next update. It is
+// safe to call Do from any goroutine.
+func Do(f func()) {
+	uiScheduler.mutex.Lock()
+	defer uiScheduler.mutex.Unlock()
+	uiScheduler.queue = append(uiScheduler.queue, f)
+}
+
+// After calls f once on the UI goroutine after the duration d, unless ctx
+// is done before that. It is safe to call After from any goroutine.
+func After(ctx context.Context, d time.Duration, f func()) {
+	uiScheduler.add(&scheduledTimer{ctx: ctx, remaining: d, f: f})
+}
+
+// Every calls f on the UI goroutine every duration d, until ctx is done.
+// If the updates are slower than d, f is called at most once per update.
+// It is safe to call Every from any goroutine.
+func Every(ctx context.Context, d time.Duration, f func()) {
+	if d <= 0 {
+		panic("Every: the duration must be positive")
+	}
+	uiScheduler.add(&scheduledTimer{ctx: ctx, remaining: d, every: d, f: f})
+}
+
+func (s *scheduler) add(t *scheduledTimer) {
+	if t.ctx == nil {
+		t.ctx = context.Background()
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.timers = append(s.timers, t)
+}
+
+// run calls the queued functions, and then advances the timers by elapsed
+// and calls the ones that are due. The functions are called without the
+// lock held, so they may call Do, After and Every themselves. It returns
+// whether any function was called.
+func (s *scheduler) run(elapsed time.Duration) (ran bool) {
+	s.mutex.Lock()
+	queue := s.queue
+	s.queue = nil
+	s.mutex.Unlock()
+
+	for _, f := range queue {
+		f()
+	}
+
+	s.mutex.Lock()
+	s.now += elapsed
+	var due []*scheduledTimer
+	kept := s.timers[:0]
+	for _, t := range s.timers {
+		if t.ctx.Err() != nil {
+			continue
+		}
+		t.remaining -= elapsed
+		if t.remaining <= 0 {
+			due = append(due, t)
+			if t.every == 0 {
+				continue
+			}
+			t.remaining += t.every
+			if t.remaining <= 0 {
+				t.remaining = t.every
+			}
+		}
+		kept = append(kept, t)
+	}
+	clear(s.timers[len(kept):])
+	s.timers = kept
+	s.mutex.Unlock()
+
+	for _, t := range due {
+		// The context might have been cancelled by an earlier function.
+		if t.ctx.Err() == nil {
+			t.f()
+			ran = true
+		}
+	}
+	return ran || len(queue) > 0
+}
+
+// next returns the time until the next timer is due, or 0 if functions are
+// queued. ok is false if nothing is scheduled.
+func (s *scheduler) next() (d time.Duration, ok bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.queue) > 0 {
+		return 0, true
+	}
+	for _, t := range s.timers {
+		if t.ctx.Err() != nil {
+			continue
+		}
+		if !ok || t.remaining < d {
+			d, ok = t.remaining, true
+		}
+	}
+	return d, ok
+}
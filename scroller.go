@@ -7,6 +7,18 @@ type Scroller struct {
 	onChanged  func(*Scroller)
 }
 
+// SetValue sets the value, limited to the range.
+func (s *Scroller) SetValue(value int) {
+	s.RangeValue.SetValue(value)
+	Invalidate(s)
+}
+
+// SetRange sets the range, and limits the value to it.
+func (s *Scroller) SetRange(min, max int) {
+	s.RangeValue.SetRange(min, max)
+	Invalidate(s)
+}
+
 func (s *Scroller) OnChanged(f func(*Scroller)) {
 	s.onChanged = f
 }
@@ -1,12 +1,104 @@
 package ui
 
+// AnchorTarget is the edge or the center of a Slab an Anchor pins to.
+type AnchorTarget int
+
+const (
+	AnchorNone   AnchorTarget = iota // AnchorNone does not pin.
+	AnchorStart                      // AnchorStart is the left or top edge.
+	AnchorCenter                     // AnchorCenter is the center.
+	AnchorEnd                        // AnchorEnd is the right or bottom edge.
+)
+
+// Anchor pins an edge or the center of a widget to an edge or the center of
+// a Slab, with an offset. A positive offset is to the right or down, so an
+// anchor to the end normally has a negative offset.
+type Anchor struct {
+	To     AnchorTarget
+	Offset int
+}
+
+// PinStart returns an anchor to the left or top edge of the slab.
+func PinStart(offset int) Anchor {
+	return Anchor{To: AnchorStart, Offset: offset}
+}
+
+// PinCenter returns an anchor to the center of the slab.
+func PinCenter(offset int) Anchor {
+	return Anchor{To: AnchorCenter, Offset: offset}
+}
+
+// PinEnd returns an anchor to the right or bottom edge of the slab.
+func PinEnd(offset int) Anchor {
+	return Anchor{To: AnchorEnd, Offset: offset}
+}
+
+// position returns the position of the anchor in a space of size.
+func (a Anchor) position(size int) int {
+	switch a.To {
+	case AnchorCenter:
+		return size/2 + a.Offset
+	case AnchorEnd:
+		return size + a.Offset
+	default:
+		return a.Offset
+	}
+}
+
+// Anchors are the anchors of a widget in a Slab. If both the left and the
+// right edge, or the top and the bottom edge are anchored, the widget is
+// stretched between them. The center anchors center the widget on the
+// anchor. Width and Height are the sizes of the widget in percent of the
+// size of the slab, or 0 for the size of the widget itself. An axis without
+// anchors keeps the position the widget was appended at. From right to left,
+// the horizontal positions are mirrored, so Left and PinStart are on the
+// right side of the slab.
+type Anchors struct {
+	Left, Right, Top, Bottom Anchor
+	CenterX, CenterY         Anchor
+	Width, Height            float64
+}
+
+// horizontal returns whether the widget is pinned horizontally.
+func (a Anchors) horizontal() bool {
+	return a.Left.To != AnchorNone || a.Right.To != AnchorNone || a.CenterX.To != AnchorNone
+}
+
+// resolveAnchor returns the position and size along one axis, in a space
+// of size, for a widget of size natural at position fixed.
+func resolveAnchor(start, center, end Anchor, percent float64, space, natural, fixed int) (pos, size int) {
+	size = natural
+	if percent > 0 {
+		size = int(float64(space) * percent / 100)
+	}
+	switch {
+	case start.To != AnchorNone && end.To != AnchorNone:
+		pos = start.position(space)
+		size = max(0, end.position(space)-pos)
+	case start.To != AnchorNone:
+		pos = start.position(space)
+	case end.To != AnchorNone:
+		pos = end.position(space) - size
+	case center.To != AnchorNone:
+		pos = center.position(space) - size/2
+	default:
+		pos = fixed
+	}
+	return pos, size
+}
+
 // A Slab is a container with fixed layout, no border and no background.
 // The minimum size fits all widgets, which are given their minimal size and
 // never repositioned after adding them. Slab is useful for fixed layouts,
 // but should normally only be used for implementing other widgets.
+//
+// Widgets appended with AppendAnchored are pinned to the edges or the center
+// of the slab in stead, so they follow when the slab is resized. A slab
+// with anchored widgets takes all the space that is available.
 type Slab struct {
 	BasicContainer
-	padded bool
+	padded  bool
+	anchors map[Control]Anchors
 }
 
 func (b *Slab) Destroy() {
@@ -39,6 +131,41 @@ func (b *Slab) Append(c Control, x, y int) {
 	c.MoveWidget(x+margin, y+margin)
 }
 
+// AppendAnchored appends c pinned with the anchors.
+func (b *Slab) AppendAnchored(c Control, anchors Anchors) {
+	b.setAnchors(c, anchors)
+	b.Append(c, 0, 0)
+}
+
+// SetAnchors changes the anchors of c.
+func (b *Slab) SetAnchors(c Control, anchors Anchors) {
+	b.setAnchors(c, anchors)
+	NeedLayout(b)
+}
+
+func (b *Slab) setAnchors(c Control, anchors Anchors) {
+	if b.anchors == nil {
+		b.anchors = map[Control]Anchors{}
+	}
+	b.anchors[c] = anchors
+}
+
+// Anchors returns the anchors of c, and whether c is anchored.
+func (b Slab) Anchors(c Control) (anchors Anchors, ok bool) {
+	anchors, ok = b.anchors[c]
+	return anchors, ok
+}
+
+func (b *Slab) Delete(index int) {
+	if index < 0 || index >= len(b.controls) {
+		return
+	}
+	child := b.controls[index]
+	delete(b.anchors, child)
+	b.BasicContainer.Delete(index)
+	child.SetParent(nil)
+}
+
 func (b Slab) Padded() bool {
 	return b.padded
 }
@@ -70,7 +197,7 @@ func (b *Slab) LayoutWidget(width, height int) {
 	availableHeight := height
 
 	for _, child := range b.controls {
-		if child.Hidden() {
+		if _, anchored := b.anchors[child]; anchored || child.Hidden() {
 			continue
 		}
 		// widget can layout freely, so use maximum dimensions
@@ -90,10 +217,44 @@ func (b *Slab) LayoutWidget(width, height int) {
 
 	h += margin * 2
 	w += margin * 2
+	if len(b.anchors) > 0 {
+		// The anchors are relative to the available space.
+		if layoutBounded(width) {
+			w = width
+		}
+		if layoutBounded(height) {
+			h = height
+		}
+		b.layoutAnchored(w-margin*2, h-margin*2, margin)
+	}
 	b.width, b.height = w, h
 	b.ClipTo(width, height)
 }
 
+// layoutAnchored lays out and moves the anchored widgets in the space.
+func (b *Slab) layoutAnchored(width, height, margin int) {
+	for _, child := range b.controls {
+		anchors, anchored := b.anchors[child]
+		if !anchored || child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(max(1, width), max(1, height))
+		naturalWidth, naturalHeight := child.WidgetSize()
+		fixedX, fixedY := child.WidgetAt()
+		x, cw := resolveAnchor(anchors.Left, anchors.CenterX, anchors.Right,
+			anchors.Width, width, naturalWidth, fixedX-margin)
+		y, ch := resolveAnchor(anchors.Top, anchors.CenterY, anchors.Bottom,
+			anchors.Height, height, naturalHeight, fixedY-margin)
+		if cw != naturalWidth || ch != naturalHeight {
+			sizeWidget(child, cw, ch)
+		}
+		if b.RightToLeft() && anchors.horizontal() {
+			x = width - x - cw
+		}
+		child.MoveWidget(x+margin, y+margin)
+	}
+}
+
 func (b Slab) DrawWidget(g *Graphic) {
 	// dx, dy := b.WidgetAbsolute()
 	// FillFrameStyle(g, dx, dy, b.width, b.height, b.Style())
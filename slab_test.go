@@ -0,0 +1,45 @@
+package ui
+
+import "testing"
+
+func TestResolveAnchor(t *testing.T) {
+	tests := []struct {
+		name               string
+		start, center, end Anchor
+		percent            float64
+		pos, size          int
+	}{
+		{"fixed", Anchor{}, Anchor{}, Anchor{}, 0, 7, 20},
+		{"start", PinStart(5), Anchor{}, Anchor{}, 0, 5, 20},
+		{"end", Anchor{}, Anchor{}, PinEnd(-5), 0, 75, 20},
+		{"center", Anchor{}, PinCenter(0), Anchor{}, 0, 40, 20},
+		{"both", PinStart(10), Anchor{}, PinEnd(-20), 0, 10, 70},
+		{"percent", PinStart(0), Anchor{}, Anchor{}, 50, 0, 50},
+		{"smaller percent", Anchor{}, Anchor{}, PinEnd(0), 10, 90, 10},
+	}
+	for _, tt := range tests {
+		pos, size := resolveAnchor(tt.start, tt.center, tt.end, tt.percent, 100, 20, 7)
+		if pos != tt.pos || size != tt.size {
+			t.Errorf("%s: %d, %d, want %d, %d", tt.name, pos, size, tt.pos, tt.size)
+		}
+	}
+}
+
+func TestSlabAnchoredSize(t *testing.T) {
+	slab := NewSlab()
+	small := newLayoutRecorder(40, 40)
+	slab.AppendAnchored(small, Anchors{Right: PinEnd(0), Bottom: PinEnd(0), Width: 10, Height: 10})
+	slab.LayoutWidget(200, 100)
+	margin := slab.Style().Margin.Int()
+	width, height := 200-margin*2, 100-margin*2
+	cw, ch := width/10, height/10
+	if w, h := small.WidgetSize(); w != cw || h != ch {
+		t.Errorf("size %dx%d, want %dx%d", w, h, cw, ch)
+	}
+	if x, y := small.WidgetAt(); x != margin+width-cw || y != margin+height-ch {
+		t.Errorf("at %d, %d, want %d, %d", x, y, margin+width-cw, margin+height-ch)
+	}
+	if last := small.layouts[len(small.layouts)-1]; last != [2]int{cw, ch} {
+		t.Errorf("laid out in %v, want %dx%d", last, cw, ch)
+	}
+}
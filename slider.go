@@ -53,16 +53,30 @@ type Slider struct {
 	active     bool
 	tickmarks  int
 	onChanged  func(*Slider)
+	binding    widgetBinding
 }
 
 func (s *Slider) SetTitle(title string) {
 	s.text.text = title
+	Invalidate(s)
 }
 
 func (s *Slider) Title() string {
 	return s.text.text
 }
 
+// SetValue sets the value, limited to the range.
+func (s *Slider) SetValue(value int) {
+	s.RangeValue.SetValue(value)
+	Invalidate(s)
+}
+
+// SetRange sets the range, and limits the value to it.
+func (s *Slider) SetRange(min, max int) {
+	s.RangeValue.SetRange(min, max)
+	Invalidate(s)
+}
+
 func (s *Slider) OnChanged(f func(*Slider)) {
 	s.onChanged = f
 }
@@ -73,6 +87,7 @@ func newSlider(min, max int) *Slider {
 		panic("NewSlider: min must be strictly smaller than max")
 	}
 	b.min = min
+	b.focusable = true
 	b.value = min
 	b.max = max
 
@@ -222,6 +237,9 @@ func (s *Slider) HandleWidget(ev Event) {
 	if mc, ok := ev.(*MouseClickEvent); ok {
 		s.HandleMouseClick(mc)
 	}
+	if _, ok := ev.(*FocusEvent); ok {
+		s.active = true
+	}
 	if s.active {
 		// allow keyboard selection
 		if ke, ok := ev.(*KeyPressEvent); ok {
@@ -231,4 +249,5 @@ func (s *Slider) HandleWidget(ev Event) {
 			s.HandleKeyRelease(ke)
 		}
 	}
+	s.binding.changed()
 }
@@ -0,0 +1,283 @@
+package ui
+
+import "image"
+import "image/draw"
+import "math"
+import "strings"
+import "sync"
+
+import "golang.org/x/image/font"
+import "golang.org/x/image/math/fixed"
+import "golang.org/x/image/vector"
+
+// SoftRenderer is a Renderer that draws on an image.RGBA in pure Go, without
+// a GPU. Widgets draw on the Graphic returned by Target, which is only a
+// handle that the drawing helpers use to find the SoftRenderer.
+//
+// The software renderer can draw the graphics made by NewGraphicFromImage,
+// the atlas sprites and the targets of other software renderers, but not
+// graphics that were drawn on with ebiten. So the video of a MediaPlayer,
+// graphics made with NewGraphic and the debug overlay of the window are not
+// drawn. The opening animation of a Pane is skipped, and a Cache draws its
+// child directly.
+type SoftRenderer struct {
+	Image   *image.RGBA
+	clip    image.Rectangle
+	target  *Graphic
+	root    *SoftRenderer
+	handles []*Graphic // handles of the renderer and its clips, only in root.
+}
+
+// softRenderers maps the target handles to their SoftRenderer.
+var softRenderers sync.Map
+
+func softRendererOf(g *Graphic) *SoftRenderer {
+	if s, ok := softRenderers.Load(g); ok {
+		return s.(*SoftRenderer)
+	}
+	return nil
+}
+
+// NewSoftRenderer returns a software renderer that draws on img.
+// Call Close when done to release the target handles.
+func NewSoftRenderer(img *image.RGBA) *SoftRenderer {
+	s := &SoftRenderer{Image: img, clip: img.Bounds()}
+	s.root = s
+	s.register()
+	return s
+}
+
+func (s *SoftRenderer) register() {
+	// A zero ebiten image is considered disposed by ebiten,
+	// so drawing on it directly with ebiten does nothing.
+	s.target = &Graphic{}
+	s.root.handles = append(s.root.handles, s.target)
+	softRenderers.Store(s.target, s)
+}
+
+// Close releases the target handles of the renderer and of all its clips.
+func (s *SoftRenderer) Close() {
+	for _, handle := range s.root.handles {
+		softRenderers.Delete(handle)
+	}
+	s.root.handles = nil
+}
+
+func (s *SoftRenderer) Target() *Graphic {
+	return s.target
+}
+
+func (s *SoftRenderer) Bounds() image.Rectangle {
+	return s.clip
+}
+
+func (s *SoftRenderer) Clip(x, y, w, h int) Renderer {
+	c := &SoftRenderer{Image: s.Image, root: s.root}
+	c.clip = image.Rect(x, y, x+w, y+h).Intersect(s.clip)
+	c.register()
+	return c
+}
+
+func (s *SoftRenderer) Fill(col Color) {
+	draw.Draw(s.Image, s.clip, image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+func (s *SoftRenderer) FillRect(x, y, w, h int, col Color) {
+	rect := image.Rect(x, y, x+w, y+h).Intersect(s.clip)
+	draw.Draw(s.Image, rect, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// rasterize draws the path built by path with anti-aliasing.
+// The path uses absolute coordinates, bounds should contain it.
+func (s *SoftRenderer) rasterize(bounds image.Rectangle, col Color, path func(p *softPath)) {
+	rect := bounds.Intersect(s.clip)
+	if rect.Empty() {
+		return
+	}
+	// The rasterizer mask starts at rect.Min, and it clips the parts of
+	// the path that are outside of it.
+	z := vector.NewRasterizer(rect.Dx(), rect.Dy())
+	path(&softPath{z: z, dx: float32(rect.Min.X), dy: float32(rect.Min.Y)})
+	z.Draw(s.Image, rect, image.NewUniform(col), image.Point{})
+}
+
+func (s *SoftRenderer) StrokeRect(x, y, w, h, t int, col Color) {
+	t2 := float32(t) / 2
+	fx, fy, fw, fh := float32(x), float32(y), float32(w), float32(h)
+	bounds := image.Rect(x-t, y-t, x+w+t, y+h+t)
+	s.rasterize(bounds, col, func(p *softPath) {
+		p.rect(fx-t2, fy-t2, fw+2*t2, fh+2*t2, false)
+		if fw > 2*t2 && fh > 2*t2 {
+			p.rect(fx+t2, fy+t2, fw-2*t2, fh-2*t2, true)
+		}
+	})
+}
+
+func (s *SoftRenderer) StrokeLine(x, y, w, h, t int, col Color) {
+	length := math.Hypot(float64(w), float64(h))
+	if length == 0 || t < 1 {
+		return
+	}
+	nx := float32(-float64(h) / length * float64(t) / 2)
+	ny := float32(float64(w) / length * float64(t) / 2)
+	x1, y1 := float32(x), float32(y)
+	x2, y2 := float32(x+w), float32(y+h)
+	bounds := image.Rect(x, y, x+w, y+h).Canon().Inset(-t)
+	s.rasterize(bounds, col, func(p *softPath) {
+		p.moveTo(x1+nx, y1+ny)
+		p.lineTo(x2+nx, y2+ny)
+		p.lineTo(x2-nx, y2-ny)
+		p.lineTo(x1-nx, y1-ny)
+		p.close()
+	})
+}
+
+func (s *SoftRenderer) StrokeCircle(cx, cy, r, t int, col Color) {
+	t2 := float32(t) / 2
+	bounds := image.Rect(cx-r-t, cy-r-t, cx+r+t+1, cy+r+t+1)
+	s.rasterize(bounds, col, func(p *softPath) {
+		p.circle(float32(cx), float32(cy), float32(r)+t2, false)
+		if inner := float32(r) - t2; inner > 0 {
+			p.circle(float32(cx), float32(cy), inner, true)
+		}
+	})
+}
+
+func (s *SoftRenderer) FillCircle(cx, cy, r int, col Color) {
+	bounds := image.Rect(cx-r-1, cy-r-1, cx+r+1, cy+r+1)
+	s.rasterize(bounds, col, func(p *softPath) {
+		p.circle(float32(cx), float32(cy), float32(r), false)
+	})
+}
+
+func (s *SoftRenderer) DrawText(str string, face Face, x, y int, col Color) {
+	dst, ok := s.Image.SubImage(s.clip).(*image.RGBA)
+	if !ok || dst.Rect.Empty() {
+		return
+	}
+	drawer := font.Drawer{Dst: dst, Src: image.NewUniform(col), Face: face}
+	height := face.Metrics().Height
+	dot := fixed.P(x, y)
+	for _, line := range strings.Split(str, "\n") {
+		drawer.Dot = dot
+		drawer.DrawString(line)
+		dot.Y += height
+	}
+}
+
+func (s *SoftRenderer) DrawGraphic(src *Graphic, x, y, w, h int, col Color) {
+	img := GraphicSource(src)
+	if img == nil || w <= 0 || h <= 0 {
+		return
+	}
+	sb := img.Bounds()
+	if sb.Empty() {
+		return
+	}
+	cr, cg, cb, ca := col.RGBA()
+	rect := image.Rect(x, y, x+w, y+h).Intersect(s.clip)
+	rgba, _ := img.(*image.RGBA)
+	for py := rect.Min.Y; py < rect.Max.Y; py++ {
+		sy := sb.Min.Y + (py-y)*sb.Dy()/h
+		for px := rect.Min.X; px < rect.Max.X; px++ {
+			sx := sb.Min.X + (px-x)*sb.Dx()/w
+			var r, g, b, a uint32
+			if rgba != nil {
+				i := rgba.PixOffset(sx, sy)
+				p := rgba.Pix[i : i+4 : i+4]
+				r, g, b, a = uint32(p[0])*0x101, uint32(p[1])*0x101, uint32(p[2])*0x101, uint32(p[3])*0x101
+			} else {
+				r, g, b, a = img.At(sx, sy).RGBA()
+			}
+			s.blend(px, py, r*cr/0xffff, g*cg/0xffff, b*cb/0xffff, a*ca/0xffff)
+		}
+	}
+}
+
+// blend draws a premultiplied 16 bit color over the pixel at x, y.
+func (s *SoftRenderer) blend(x, y int, r, g, b, a uint32) {
+	if a == 0 {
+		return
+	}
+	i := s.Image.PixOffset(x, y)
+	p := s.Image.Pix[i : i+4 : i+4]
+	ia := 0xffff - a
+	p[0] = uint8((r + uint32(p[0])*0x101*ia/0xffff) >> 8)
+	p[1] = uint8((g + uint32(p[1])*0x101*ia/0xffff) >> 8)
+	p[2] = uint8((b + uint32(p[2])*0x101*ia/0xffff) >> 8)
+	p[3] = uint8((a + uint32(p[3])*0x101*ia/0xffff) >> 8)
+}
+
+var _ Renderer = &SoftRenderer{}
+
+// softPath builds a path on a rasterizer with an offset.
+// The rasterizer fills using the absolute value of the winding,
+// so a reversed inner shape cuts a hole in an outer shape.
+type softPath struct {
+	z      *vector.Rasterizer
+	dx, dy float32
+}
+
+func (p *softPath) moveTo(x, y float32) {
+	p.z.MoveTo(x-p.dx, y-p.dy)
+}
+
+func (p *softPath) lineTo(x, y float32) {
+	p.z.LineTo(x-p.dx, y-p.dy)
+}
+
+func (p *softPath) cubeTo(x1, y1, x2, y2, x, y float32) {
+	p.z.CubeTo(x1-p.dx, y1-p.dy, x2-p.dx, y2-p.dy, x-p.dx, y-p.dy)
+}
+
+func (p *softPath) close() {
+	p.z.ClosePath()
+}
+
+func (p *softPath) rect(x, y, w, h float32, reverse bool) {
+	p.moveTo(x, y)
+	if reverse {
+		p.lineTo(x, y+h)
+		p.lineTo(x+w, y+h)
+		p.lineTo(x+w, y)
+	} else {
+		p.lineTo(x+w, y)
+		p.lineTo(x+w, y+h)
+		p.lineTo(x, y+h)
+	}
+	p.close()
+}
+
+// circle adds a circle made of four cubic Bézier curves.
+func (p *softPath) circle(cx, cy, r float32, reverse bool) {
+	const kappa = 0.5522848
+	k := r * kappa
+	// Start at the right, then go through bottom, left and top,
+	// or mirrored through top, left and bottom if reversed.
+	dy, ky := r, k
+	if reverse {
+		dy, ky = -r, -k
+	}
+	p.moveTo(cx+r, cy)
+	p.cubeTo(cx+r, cy+ky, cx+k, cy+dy, cx, cy+dy)
+	p.cubeTo(cx-k, cy+dy, cx-r, cy+ky, cx-r, cy)
+	p.cubeTo(cx-r, cy-ky, cx-k, cy-dy, cx, cy-dy)
+	p.cubeTo(cx+k, cy-dy, cx+r, cy-ky, cx+r, cy)
+	p.close()
+}
+
+// DrawSoftware draws the control c on img with the software renderer.
+// c should be laid out already.
+func DrawSoftware(c Control, img *image.RGBA) {
+	s := NewSoftRenderer(img)
+	defer s.Close()
+	c.DrawWidget(s.Target())
+}
+
+// RenderSoftware draws the control c with the software renderer on a new
+// image of the given size, and returns the image.
+func RenderSoftware(c Control, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	DrawSoftware(c, img)
+	return img
+}
@@ -0,0 +1,44 @@
+package ui
+
+import "image"
+import "image/color"
+import "testing"
+
+func TestDrawGraphicAtSoft(t *testing.T) {
+	red := color.RGBA{255, 0, 0, 255}
+	srcImage := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			srcImage.SetRGBA(x, y, red)
+		}
+	}
+	src := NewSoftRenderer(srcImage)
+	defer src.Close()
+
+	tests := []struct {
+		name   string
+		draw   func(target *Graphic)
+		inside image.Rectangle
+	}{
+		{"at", func(target *Graphic) {
+			DrawGraphicAt(target, src.Target(), 1, 1)
+		}, image.Rect(1, 1, 3, 3)},
+		{"at scale", func(target *Graphic) {
+			DrawGraphicAtScale(target, src.Target(), 1, 1, 2, 1.5)
+		}, image.Rect(1, 1, 5, 4)},
+	}
+	for _, tt := range tests {
+		dstImage := image.NewRGBA(image.Rect(0, 0, 6, 6))
+		dst := NewSoftRenderer(dstImage)
+		tt.draw(dst.Target())
+		dst.Close()
+		for y := 0; y < 6; y++ {
+			for x := 0; x < 6; x++ {
+				got := dstImage.RGBAAt(x, y) == red
+				if want := image.Pt(x, y).In(tt.inside); got != want {
+					t.Errorf("%s: pixel %d,%d drawn %v, want %v", tt.name, x, y, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package ui
+
+import "time"
+
+// splitterDivider is the thickness of the divider of a Splitter.
+const splitterDivider = 6
+
+// splitterDoubleClick is the maximum time between the clicks of a double
+// click on the divider.
+const splitterDoubleClick = 400 * time.Millisecond
+
+// Splitter is a container with two widgets, next to each other or the one
+// above the other, separated by a divider that the user can drag to resize
+// them. Double clicking the divider collapses the first widget to the edge,
+// or restores it. From right to left, the first widget is on the right.
+// The splitter takes all the space that is available. If the space is
+// unlimited, it takes the size the widgets need next to each other.
+type Splitter struct {
+	BasicContainer
+	vertical  bool    // vertical is true if the widgets are above each other.
+	ratio     float64 // ratio is the share of the first widget of the space.
+	minFirst  int     // minFirst is the minimum size of the first widget.
+	minSecond int     // minSecond is the minimum size of the second widget.
+	collapsed bool    // collapsed is true if the first widget is collapsed.
+	sizes     [2]int  // sizes are the sizes of the widgets along the split.
+	dragging  bool
+	hovering  bool
+	clicked   bool          // clicked is true after a click on the divider.
+	lastClick time.Duration // lastClick is the uiTime of that click.
+	onChanged func(*Splitter)
+}
+
+// NewSplitter returns a splitter with first and second next to each other,
+// or above each other if vertical is true. Both get half of the space.
+func NewSplitter(vertical bool, first, second Control) *Splitter {
+	s := &Splitter{vertical: vertical, ratio: 0.5}
+	s.controls = []Control{}
+	s.AppendWithParent(first, s)
+	s.AppendWithParent(second, s)
+	return s
+}
+
+// First returns the first widget, at the start or at the top.
+func (s *Splitter) First() Control {
+	return s.controls[0]
+}
+
+// Second returns the second widget, at the end or at the bottom.
+func (s *Splitter) Second() Control {
+	return s.controls[1]
+}
+
+// Vertical returns whether the widgets are above each other.
+func (s Splitter) Vertical() bool {
+	return s.vertical
+}
+
+// Ratio returns the share of the space of the first widget, from 0 to 1.
+func (s Splitter) Ratio() float64 {
+	return s.ratio
+}
+
+// SetRatio sets the share of the space of the first widget, from 0 to 1.
+// The minimum sizes still apply. It also restores a collapsed splitter.
+func (s *Splitter) SetRatio(ratio float64) {
+	s.ratio = min(1, max(0, ratio))
+	s.collapsed = false
+	NeedLayout(s)
+}
+
+// SetMinSizes sets the minimum sizes of the first and the second widget
+// along the split.
+func (s *Splitter) SetMinSizes(first, second int) {
+	s.minFirst = max(0, first)
+	s.minSecond = max(0, second)
+	NeedLayout(s)
+}
+
+// Collapsed returns whether the first widget is collapsed.
+func (s Splitter) Collapsed() bool {
+	return s.collapsed
+}
+
+// SetCollapsed collapses the first widget to the edge, or restores it to
+// its ratio.
+func (s *Splitter) SetCollapsed(collapsed bool) {
+	s.collapsed = collapsed
+	NeedLayout(s)
+}
+
+// OnChanged sets a callback that is called when the user resized, collapsed
+// or restored the widgets.
+func (s *Splitter) OnChanged(cb func(*Splitter)) {
+	s.onChanged = cb
+}
+
+// axes converts a width and height to a size along and across the split,
+// or back.
+func (s Splitter) axes(a, b int) (int, int) {
+	if s.vertical {
+		return b, a
+	}
+	return a, b
+}
+
+// directed mirrors a position along the split of something of size, if the
+// widgets are next to each other and laid out from right to left.
+func (s Splitter) directed(pos, size int) int {
+	if s.vertical || !s.RightToLeft() {
+		return pos
+	}
+	return s.width - pos - size
+}
+
+// firstSize returns the size of the first widget for the space of both.
+func (s Splitter) firstSize(space int) int {
+	if s.collapsed {
+		return 0
+	}
+	size := int(s.ratio * float64(space))
+	size = min(size, space-s.minSecond)
+	size = max(size, s.minFirst)
+	return min(max(0, size), space)
+}
+
+// measure lays out the widgets with their preferred size, and returns the
+// size the splitter needs for them and the divider.
+func (s *Splitter) measure(width, height int) (needWidth, needHeight int) {
+	needMain, needCross := splitterDivider, 0
+	for i, child := range s.controls {
+		child.LayoutWidget(max(1, width), max(1, height))
+		main, cross := s.axes(child.WidgetSize())
+		if i == 0 {
+			main = max(main, s.minFirst)
+		} else {
+			main = max(main, s.minSecond)
+		}
+		needMain += main
+		needCross = max(needCross, cross)
+	}
+	return s.axes(needMain, needCross)
+}
+
+func (s *Splitter) LayoutWidget(width, height int) {
+	if !layoutBounded(width) || !layoutBounded(height) {
+		needWidth, needHeight := s.measure(width, height)
+		if !layoutBounded(width) {
+			width = needWidth
+		}
+		if !layoutBounded(height) {
+			height = needHeight
+		}
+	}
+	s.width, s.height = width, height
+	s.layoutChildren()
+	s.ClipTo(width, height)
+	s.BasicContainer.UpdateOrdered()
+}
+
+// layoutChildren lays out the widgets on both sides of the divider.
+func (s *Splitter) layoutChildren() {
+	main, cross := s.axes(s.width, s.height)
+	space := max(0, main-splitterDivider)
+	s.sizes[0] = s.firstSize(space)
+	s.sizes[1] = space - s.sizes[0]
+	pos := 0
+	for i, child := range s.controls {
+		if s.sizes[i] > 0 && cross > 0 {
+			width, height := s.axes(s.sizes[i], cross)
+			child.LayoutWidget(width, height)
+			stretchWidget(child, width, height)
+		}
+		child.MoveWidget(s.axes(s.directed(pos, s.sizes[i]), 0))
+		pos += s.sizes[i] + splitterDivider
+	}
+}
+
+// divider returns the position and size of the divider, relative to the
+// splitter.
+func (s Splitter) divider() (x, y, width, height int) {
+	_, cross := s.axes(s.width, s.height)
+	x, y = s.axes(s.directed(s.sizes[0], splitterDivider), 0)
+	width, height = s.axes(splitterDivider, cross)
+	return x, y, width, height
+}
+
+func (s Splitter) cursorShape() CursorShapeType {
+	if s.vertical {
+		return CursorShapeNSResize
+	}
+	return CursorShapeEWResize
+}
+
+func (s *Splitter) onDivider(me MouseEvent) bool {
+	x, y, width, height := s.divider()
+	return s.MouseInsidePart(x, y, width, height, me)
+}
+
+// dragTo moves the divider to the mouse position.
+func (s *Splitter) dragTo(me MouseEvent) {
+	dx, dy := s.WidgetAbsolute()
+	pos, _ := s.axes(me.X-dx, me.Y-dy)
+	pos = s.directed(pos, 0)
+	main, _ := s.axes(s.width, s.height)
+	space := main - splitterDivider
+	if space <= 0 {
+		return
+	}
+	s.ratio = min(1, max(0, float64(pos-splitterDivider/2)/float64(space)))
+	s.collapsed = false
+	s.layoutChildren()
+	Invalidate(s)
+	s.changed()
+}
+
+func (s *Splitter) changed() {
+	if s.onChanged != nil {
+		s.onChanged(s)
+	}
+}
+
+func (s *Splitter) HandleWidget(ev Event) {
+	switch e := ev.(type) {
+	case *MouseClickEvent:
+		if s.onDivider(e.MouseEvent) {
+			now := uiTime()
+			if s.clicked && now-s.lastClick < splitterDoubleClick {
+				s.clicked = false
+				s.SetCollapsed(!s.collapsed)
+				s.changed()
+				return
+			}
+			s.clicked, s.lastClick = true, now
+			s.dragging = true
+			SetCursorShape(s.cursorShape())
+			return
+		}
+	case *MouseReleaseEvent:
+		if s.dragging {
+			s.dragging = false
+			SetCursorShape(CursorShapeDefault)
+			return
+		}
+	case *MouseMoveEvent:
+		if s.dragging {
+			s.dragTo(e.MouseEvent)
+			return
+		}
+		if s.onDivider(e.MouseEvent) {
+			s.hovering = true
+			SetCursorShape(s.cursorShape())
+		} else if s.hovering {
+			s.hovering = false
+			SetCursorShape(CursorShapeDefault)
+		}
+	}
+	s.BasicContainer.HandleWidget(ev)
+}
+
+func (s Splitter) DrawWidget(g *Graphic) {
+	dx, dy := s.WidgetAbsolute()
+	_, cross := s.axes(s.width, s.height)
+	for i, child := range s.controls {
+		if child.Hidden() || s.sizes[i] <= 0 {
+			continue
+		}
+		cx, cy := child.WidgetAt()
+		width, height := s.axes(s.sizes[i], cross)
+		child.DrawWidget(GraphicClip(g, dx+cx, dy+cy, width, height))
+	}
+
+	style := theme.Button
+	if s.dragging || s.hovering {
+		style = theme.Hover
+	}
+	x, y, width, height := s.divider()
+	FillFrameStyle(g, dx+x, dy+y, width, height, *style)
+	s.DrawDebug(g, "SPL")
+}
+
+func (s *Splitter) Destroy() {
+	for _, child := range s.controls {
+		child.SetParent(nil)
+		child.Destroy()
+	}
+}
+
+var _ Control = &Splitter{}
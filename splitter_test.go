@@ -0,0 +1,29 @@
+package ui
+
+import "testing"
+
+func TestSplitterSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		vertical      bool
+		width, height int
+		want          [2]int
+	}{
+		{"bounded", false, 200, 100, [2]int{200, 100}},
+		{"unlimited", false, LayoutUnlimited, LayoutUnlimited, [2]int{30 + 50 + splitterDivider, 40}},
+		{"unlimited width", false, LayoutUnlimited, 100, [2]int{30 + 50 + splitterDivider, 100}},
+		{"unlimited vertical", true, LayoutUnlimited, LayoutUnlimited, [2]int{50, 20 + 40 + splitterDivider}},
+	}
+	for _, tt := range tests {
+		first, second := newLayoutRecorder(30, 20), newLayoutRecorder(50, 40)
+		s := NewSplitter(tt.vertical, first, second)
+		s.LayoutWidget(tt.width, tt.height)
+		if w, h := s.WidgetSize(); w != tt.want[0] || h != tt.want[1] {
+			t.Errorf("%s: size %dx%d, want %v", tt.name, w, h, tt.want)
+		}
+		main, _ := s.axes(s.width, s.height)
+		if s.sizes[0]+s.sizes[1]+splitterDivider != main {
+			t.Errorf("%s: sizes %v do not fill %d", tt.name, s.sizes, main)
+		}
+	}
+}
@@ -9,6 +9,7 @@ type Tab struct {
 	bar            Tray         // the tab bar on top
 	tabs           []*tabHeader // The tab headers in the bar.
 	active         int          // index of active tab.
+	switching      *Animation   // switching slides the active page in.
 }
 
 func NewTab() *Tab {
@@ -32,6 +33,7 @@ type tabHeader struct {
 func newTabHeader(iwt *IconTextWidget, tab *Tab, index int) *tabHeader {
 	th := &tabHeader{IconTextWidget: iwt, tab: tab, index: index, pressed: false}
 	th.IconTextWidget.SetStyle(theme.Tab)
+	th.focusable = true
 	return th
 }
 
@@ -95,6 +97,7 @@ func (t *Tab) Select(index int) {
 	if index < 0 || index >= len(t.controls) {
 		return
 	}
+	previous := t.active
 	t.active = index
 	for i := 0; i < len(t.tabs); i++ {
 		t.tabs[i].pressed = (i == t.active)
@@ -106,12 +109,33 @@ func (t *Tab) Select(index int) {
 			control.Hide()
 		}
 	}
+	if previous != index && t.width > 0 {
+		t.animateSwitch(index > previous)
+	}
+}
+
+func (t *Tab) barHeight() int {
+	return t.Style().Size.Height.Int() + t.Style().Margin.Int()
+}
+
+// animateSwitch slides the active page in from the right if forward is
+// true, or from the left otherwise.
+func (t *Tab) animateSwitch(forward bool) {
+	from := -t.width
+	if forward {
+		from = t.width
+	}
+	page := t.controls[t.active]
+	stopAnimation(t.switching)
+	t.switching = AnimateInt(from, 0, animationMedium, EaseOutCubic, func(x int) {
+		page.MoveWidget(x, t.barHeight())
+	}).Invalidates(page).Start()
 }
 
 func (t *Tab) LayoutWidget(parentWidth, parentHeight int) {
 	// We use height as the height for the tab bar, not for our height,
 	// which will become the parent height minus the tab bar height
-	tabHeight := t.Style().Size.Height.Int() + t.Style().Margin.Int()
+	tabHeight := t.barHeight()
 	// lay out the bar as a tray, then...
 	t.bar.LayoutWidget(parentWidth, tabHeight)
 	t.bar.MoveWidget(0, 0)
@@ -131,8 +155,18 @@ func (t *Tab) LayoutWidget(parentWidth, parentHeight int) {
 	t.ClipTo(parentWidth, parentHeight)
 }
 
+// Parts returns the tab bar and the pages of the tab.
+func (t *Tab) Parts() []Control {
+	return append([]Control{&t.bar}, t.controls...)
+}
+
 func (t *Tab) DrawWidget(screen *Graphic) {
 	t.bar.DrawWidget(screen)
+	if t.switching.Running() {
+		// Clip the page that slides in to the tab.
+		dx, dy := t.WidgetAbsolute()
+		screen = GraphicClip(screen, dx, dy, t.width, t.height+t.barHeight())
+	}
 	t.BasicContainer.DrawWidget(screen)
 }
 
@@ -294,6 +294,7 @@ type Table struct {
 	onHeaderClicked func(*Table, int)
 	onClicked       func(*Table, int, int)
 	rowHeight       int
+	cache           widgetCache // cache of the drawn cells.
 }
 
 func (t Table) RowHeight() int {
@@ -384,11 +385,39 @@ func (t *Table) LayoutWidget(width, height int) {
 		t.from = 0
 	}
 
+	t.cache.invalidate()
 	println("Table.LayoutWidget", t.width, t.height, t.Tray.width, t.Tray.height, shownHeight, t.RowHeight(), t.shown, height)
 }
 
-func (t Table) DrawWidget(dst *Graphic) {
-	t.Tray.DrawWidget(dst)
+// DrawWidget draws the table from its cache, the cells are only drawn again
+// when the table was invalidated.
+func (t *Table) DrawWidget(dst *Graphic) {
+	dx, dy := t.WidgetAbsolute()
+	t.cache.draw(dst, dx, dy, t.width, t.height, t.Tray.DrawWidget)
+}
+
+// HandleWidget passes the event to the columns, and invalidates the cache
+// for the events that can change the table: clicks, touches, the wheel,
+// keys, text input and the focus. Mouse moves and updates don't, and the
+// columns and their widgets invalidate the table when they change.
+func (t *Table) HandleWidget(ev Event) {
+	t.Tray.HandleWidget(ev)
+	switch ev.(type) {
+	case *MouseClickEvent, *MouseReleaseEvent, *TouchPressEvent, *TouchReleaseEvent,
+		*WheelEvent, *KeyPressEvent, *KeyReleaseEvent, *CharEvent,
+		*FocusEvent, *AwayEvent:
+		t.cache.invalidate()
+	}
+}
+
+// InvalidateCache makes the table draw its cells again on the next frame.
+func (t *Table) InvalidateCache() {
+	t.cache.invalidate()
+}
+
+func (t *Table) Destroy() {
+	t.cache.release()
+	t.Tray.Destroy()
 }
 
 // AppendColumn appends a column to the table.
@@ -402,15 +431,18 @@ func (g *Table) AppendColumn(col *Column) {
 
 // ModelRowUpdated should be called whenever a row in the data model was updated.
 func (g *Table) ModelRowUpdated(index int) {
+	Invalidate(g)
 }
 
 // ModelRowCreated should be called whenever a row in the data model was created.
 // For an append index may be equal to model.NumRows()
 func (g *Table) ModelRowCreated(index int) {
+	Invalidate(g)
 }
 
 // ModelRowDeleted should be called whenever a row in the data model was deleted.
 func (g *Table) ModelRowDeleted(index int) {
+	Invalidate(g)
 }
 
 func (t *Table) SetHeaderVisible(visible bool) {
@@ -423,6 +455,7 @@ func (t *Table) SetHeaderVisible(visible bool) {
 			col.caption.Hide()
 		}
 	}
+	Invalidate(t)
 }
 
 func (t *Table) Column(index int) *Column {
@@ -615,6 +648,7 @@ func (c *Column) HandleWidget(ev Event) {
 // SetMarker sets the marker icon on the column header.
 func (c *Column) SetMarker(marker string) {
 	c.marker = marker
+	Invalidate(c)
 }
 
 // Marker returns the marker icon on the column header.
@@ -628,11 +662,13 @@ func (t *Table) ScrollWidget(y int) {
 		t.from = 0
 	}
 	t.y = 0 // -y % t.TableModel.NumRows()
+	t.cache.invalidate()
 	println("ScrollWidget", y, t.y, t.from, t.shown, t.TableModel.NumRows())
 }
 
 func (t *Table) RollWidget(x int) {
 	t.x = -x
+	t.cache.invalidate()
 }
 
 func (t *Table) ScrollSize() (width, height int) {
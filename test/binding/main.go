@@ -0,0 +1,92 @@
+package main
+
+import "fmt"
+import "strconv"
+import . "github.com/bjorndm/golang-ui"
+
+// settings is the model of the form.
+type settings struct {
+	Name    *Binding[string]
+	Volume  *Binding[int]
+	Muted   *Binding[bool]
+	Quality *Binding[int]
+	Speed   *Binding[int]
+}
+
+func mainBinding() {
+	Init()
+	w := NewWindow("test window with bindings", 640, 480, false)
+
+	model := settings{
+		Name:    NewBinding("Jane Doe"),
+		Volume:  NewBinding(50),
+		Muted:   NewBinding(false),
+		Quality: NewBinding(1),
+		Speed:   NewBinding(0),
+	}
+
+	grid := NewGrid()
+
+	name := NewEntry()
+	name.Bind(model.Name)
+	greeting := NewLabel("")
+	greetingText, _ := ConvertBinding(model.Name, func(name string) string {
+		return "Hello " + name
+	}, nil)
+	greeting.Bind(greetingText)
+	grid.Append(NewLabel("Name"), 0, 0, 1, AlignStart)
+	grid.Append(name, 1, 0, 1, AlignStart)
+	grid.Append(greeting, 2, 0, 1, AlignStart)
+
+	// The slider and the entry edit the same volume.
+	volume := NewSlider(0, 100)
+	volume.Bind(model.Volume)
+	volumeText := NewEntry()
+	volumeValue, _ := ConvertBinding(model.Volume, strconv.Itoa, strconv.Atoi)
+	volumeText.Bind(volumeValue)
+	grid.Append(NewLabel("Volume"), 0, 1, 1, AlignStart)
+	grid.Append(volume, 1, 1, 1, AlignStart)
+	grid.Append(volumeText, 2, 1, 1, AlignStart)
+
+	muted := NewCheckbox("Muted")
+	muted.Bind(model.Muted)
+	grid.Append(muted, 1, 2, 1, AlignStart)
+
+	quality := NewDropdown()
+	quality.Append("Low")
+	quality.Append("Medium")
+	quality.Append("High")
+	quality.Bind(model.Quality)
+	grid.Append(NewLabel("Quality"), 0, 3, 1, AlignStart)
+	grid.Append(quality, 1, 3, 1, AlignStart)
+
+	speed := NewRadio()
+	speedBox := NewHorizontalBox()
+	speedBox.Append(speed.Append("Slow"))
+	speedBox.Append(speed.Append("Normal"))
+	speedBox.Append(speed.Append("Fast"))
+	speed.Bind(model.Speed)
+	grid.Append(NewLabel("Speed"), 0, 4, 1, AlignStart)
+	grid.Append(speedBox, 1, 4, 1, AlignStart)
+
+	reset := NewButton("Reset")
+	reset.OnClicked(func(*Button) {
+		model.Name.Set("")
+		model.Volume.Set(50)
+		model.Muted.Set(false)
+		model.Quality.Set(1)
+		model.Speed.Set(1)
+	})
+	grid.Append(reset, 1, 5, 1, AlignStart)
+
+	model.Volume.Subscribe(func(volume int) {
+		fmt.Printf("volume: %d\n", volume)
+	})
+
+	w.SetChild(grid)
+	Main(w)
+}
+
+func main() {
+	mainBinding()
+}
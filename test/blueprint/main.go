@@ -0,0 +1,42 @@
+package main
+
+import "embed"
+import "fmt"
+import . "github.com/bjorndm/golang-ui"
+
+//go:embed form.json
+var files embed.FS
+
+func mainBlueprint() {
+	Init()
+	MountResources(files)
+	w := NewWindow("test window with blueprint", 640, 480, false)
+
+	loader := NewBlueprintLoader()
+	loader.Handle("save", func(c Control) {
+		fmt.Printf("save clicked: %v\n", c)
+	})
+	loader.Handle("changed", func(c Control) {
+		if e, ok := c.(*Entry); ok {
+			fmt.Printf("entry changed: %s\n", e.Text())
+		}
+	})
+	loader.Handle("selected", func(c Control) {
+		fmt.Printf("selected: %v\n", c)
+	})
+
+	screen, err := loader.Load("form.json")
+	if err != nil {
+		panic(err)
+	}
+	if name, ok := ScreenWidget[*Entry](screen, "name"); ok {
+		name.SetText("Jane Doe")
+	}
+
+	w.SetChild(screen.Root())
+	Main(w)
+}
+
+func main() {
+	mainBlueprint()
+}
@@ -0,0 +1,16 @@
+#!/bin/sh
+# Runs the tests and checks the golden images, as on a CI server.
+# ebiten initializes GLFW when it is imported, which needs a display on Linux
+# and BSD, so without a display the script runs itself again with a virtual
+# X server from xvfb-run.
+#
+# Usage: test/ci.sh
+
+cd "$(dirname "$0")/.." || exit 1
+
+if [ -z "$DISPLAY" ] && [ "$(go env GOOS)" != "windows" ] && [ "$(go env GOOS)" != "darwin" ]; then
+	exec xvfb-run -a "$0" "$@"
+fi
+
+go test ./... || exit 1
+test/golden.sh
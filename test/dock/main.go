@@ -0,0 +1,39 @@
+package main
+
+import "fmt"
+import . "github.com/bjorndm/golang-ui"
+
+func mainDock() {
+	Init()
+	w := NewWindow("test window with dock", 800, 600, false)
+
+	dock := NewDock()
+
+	toolbar := NewTray()
+	toolbar.Append(NewButton("New"))
+	toolbar.Append(NewButton("Open"))
+	toolbar.Append(NewButton("Save"))
+	dock.Append(toolbar, DockTop)
+
+	status := NewLabel("Ready")
+	dock.Append(status, DockBottom)
+
+	navigation := NewVerticalBox()
+	for _, page := range []string{"Customers", "Orders", "Invoices"} {
+		button := NewButton(page)
+		button.OnClicked(func(*Button) {
+			status.SetText(fmt.Sprintf("Showing %s", page))
+		})
+		navigation.Append(button)
+	}
+	dock.Append(navigation, DockLeft)
+
+	dock.Append(NewNote(), DockCenter)
+
+	w.SetChild(dock)
+	Main(w)
+}
+
+func main() {
+	mainDock()
+}
@@ -0,0 +1,46 @@
+package main
+
+import . "github.com/bjorndm/golang-ui"
+
+func mainFlex() {
+	Init()
+	w := NewWindow("test window with flex", 640, 480, false)
+
+	box := NewVerticalBox()
+
+	// A tool bar with a spacer that pushes the last button to the end.
+	toolbar := NewFlex(FlexRow)
+	toolbar.SetGap(4)
+	toolbar.SetAlign(FlexAlignCenter)
+	toolbar.Append(NewButton("New"))
+	toolbar.Append(NewButton("Open"))
+	toolbar.AppendItem(NewLabel(""), FlexItem{Grow: 1})
+	toolbar.Append(NewButton("Quit"))
+	box.Append(toolbar)
+
+	// A form row where the entry stretches.
+	row := NewFlex(FlexRow)
+	row.SetGap(4)
+	row.SetAlign(FlexAlignCenter)
+	row.Append(NewLabel("Name"))
+	row.AppendItem(NewEntry(), FlexItem{Grow: 1, Shrink: 1})
+	box.Append(row)
+
+	// Buttons that wrap to multiple lines, spread evenly.
+	tags := NewFlex(FlexRow)
+	tags.SetGap(4)
+	tags.SetWrap(true)
+	tags.SetJustify(FlexJustifySpaceEvenly)
+	for _, tag := range []string{"red", "orange", "yellow", "green", "blue",
+		"indigo", "violet", "black", "white", "gray", "brown", "pink"} {
+		tags.Append(NewButton(tag))
+	}
+	box.Append(tags)
+
+	w.SetChild(box)
+	Main(w)
+}
+
+func main() {
+	mainFlex()
+}
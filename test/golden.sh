@@ -0,0 +1,34 @@
+#!/bin/sh
+# Renders every scenario in the test directory once with the software renderer
+# and compares it with its golden image in test/golden.
+# Run as test/golden.sh -u to update the golden images after an intended
+# change of the look of the widgets.
+#
+# Usage: test/golden.sh [-u] [scenario...]
+
+cd "$(dirname "$0")/.." || exit 1
+
+export EBUI_GOLDEN=test/golden
+if [ "$1" = "-u" ]; then
+	export EBUI_GOLDEN_UPDATE=1
+	shift
+fi
+
+scenarios="$*"
+if [ -z "$scenarios" ]; then
+	for dir in test/*/main.go; do
+		scenarios="$scenarios $(basename "$(dirname "$dir")")"
+	done
+fi
+
+failed=""
+for scenario in $scenarios; do
+	if ! EBUI_GOLDEN_NAME="$scenario" go run "./test/$scenario"; then
+		failed="$failed $scenario"
+	fi
+done
+
+if [ -n "$failed" ]; then
+	echo "golden: failed:$failed"
+	exit 1
+fi
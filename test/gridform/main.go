@@ -0,0 +1,36 @@
+package main
+
+import . "github.com/bjorndm/golang-ui"
+import "github.com/bjorndm/golang-ui/icon"
+
+func mainForm() {
+	Init()
+	w := NewWindow("test grid form", 640, 480, false)
+
+	form := NewGrid()
+	form.SetGaps(4, 8)
+	// The labels take the width they need, the fields the rest.
+	form.SetColumn(0, AutoColumn())
+	form.SetColumn(1, WeightedColumn(1))
+	form.SetColumn(2, FixedColumn(96))
+
+	form.Append(NewLabel("Name"), 0, 0, 1, StyleAlignEnd)
+	form.Append(NewEntry(), 1, 0, 1, StyleAlignJustify)
+	form.Append(NewLabel("E-mail"), 0, 1, 1, StyleAlignEnd)
+	form.Append(NewEntry(), 1, 1, 1, StyleAlignJustify)
+	form.Append(NewLabel("Remarks"), 0, 2, 1, StyleAlignEnd)
+	form.Append(NewNote(), 1, 2, 1, StyleAlignJustify)
+
+	// The picture spans the rows, centered vertically.
+	picture := NewMesh(NewPictureWithIcon("", icon.Basket), 2, 0, 1, StyleAlignCenter)
+	form.AppendMesh(picture.SetRowSpan(3).SetVerticalAlign(StyleAlignMiddle))
+
+	form.Append(NewButton("Save"), 1, 3, 2, StyleAlignEnd)
+
+	w.SetChild(form)
+	Main(w)
+}
+
+func main() {
+	mainForm()
+}
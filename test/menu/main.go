@@ -32,6 +32,11 @@ func mainMenu() {
 	menuHello.AppendItem("World").OnClicked(func(it *MenuItem) {
 		fmt.Printf("World clicked\n")
 	})
+	save := menuHello.AppendItem("Save")
+	save.SetAccelerator(MustParseAccelerator("Ctrl+S"))
+	save.OnClicked(func(it *MenuItem) {
+		fmt.Printf("Save clicked\n")
+	})
 	menu := bar.AppendMenu("|Another Menu|")
 	var item7 *MenuItem
 
@@ -53,6 +58,7 @@ func mainMenu() {
 			item = menu.AppendSeparator()
 		}
 		if nr == 7 {
+			item.SetAccelerator(MustParseAccelerator("Ctrl+Shift+Z"))
 			item.Disable()
 			item7 = item
 		}
@@ -75,6 +81,10 @@ func mainMenu() {
 
 	})
 
+	w.AddAccelerator(MustParseAccelerator("F5"), func() {
+		fmt.Printf("F5 pressed\n")
+	})
+
 	w.SetChild(box)
 	w.SetMenuBar(bar)
 
@@ -0,0 +1,52 @@
+package main
+
+import . "github.com/bjorndm/golang-ui"
+
+func mainRightToLeft() {
+	Init()
+	w := NewWindow("test window right to left", 640, 480, false)
+	// All widgets inherit the direction of the window.
+	w.SetLayoutDirection(LayoutRightToLeft)
+
+	box := NewVerticalBox()
+
+	// The form is mirrored, the labels are on the right.
+	form := NewGrid()
+	form.SetGaps(4, 8)
+	form.SetColumn(0, AutoColumn())
+	form.SetColumn(1, WeightedColumn(1))
+	form.Append(NewLabel("שם"), 0, 0, 1, StyleAlignEnd)
+	name := NewEntry()
+	name.SetText("דוד כהן")
+	form.Append(name, 1, 0, 1, StyleAlignJustify)
+	form.Append(NewLabel("טלפון"), 0, 1, 1, StyleAlignEnd)
+	phone := NewEntry()
+	phone.SetText("03-1234567")
+	form.Append(phone, 1, 1, 1, StyleAlignJustify)
+	box.Append(form)
+
+	// Mixed text is reordered with the bidirectional algorithm.
+	box.Append(NewLabel("ההזמנה 1234 נשלחה ב-DHL היום."))
+	box.Append(NewLabel("مرحبا بالعالم (2025)"))
+
+	// The buttons flow from right to left.
+	tray := NewTray()
+	tray.SetGaps(4, 4)
+	tray.Append(NewButton("שמור"))
+	tray.Append(NewButton("ביטול"))
+	box.Append(tray)
+
+	// This tray stays left to right inside of the mirrored box.
+	ltr := NewTray()
+	ltr.SetLayoutDirection(LayoutLeftToRight)
+	ltr.Append(NewLabel("left"))
+	ltr.Append(NewLabel("to right"))
+	box.Append(ltr)
+
+	w.SetChild(box)
+	Main(w)
+}
+
+func main() {
+	mainRightToLeft()
+}
@@ -0,0 +1,65 @@
+package main
+
+import "context"
+import "fmt"
+import "time"
+import . "github.com/bjorndm/golang-ui"
+
+func mainScheduler() {
+	Init()
+	w := NewWindow("test window with scheduler", 640, 480, false)
+
+	box := NewVerticalBox()
+	worker := NewLabel("worker: waiting")
+	clock := NewLabel("clock: 0s")
+	alarm := NewLabel("alarm: not set")
+	box.Append(worker)
+	box.Append(clock)
+	box.Append(alarm)
+
+	// A goroutine may not change the widgets, it uses Do in stead.
+	go func() {
+		for i := 1; ; i++ {
+			time.Sleep(500 * time.Millisecond)
+			Do(func() {
+				worker.SetText(fmt.Sprintf("worker: result %d", i))
+			})
+		}
+	}()
+
+	seconds := 0
+	Every(context.Background(), time.Second, func() {
+		seconds++
+		clock.SetText(fmt.Sprintf("clock: %ds", seconds))
+	})
+
+	var cancel context.CancelFunc
+	set := NewButton("Set alarm in 3s")
+	set.OnClicked(func(*Button) {
+		if cancel != nil {
+			cancel()
+		}
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		alarm.SetText("alarm: set")
+		After(ctx, 3*time.Second, func() {
+			alarm.SetText("alarm: ring!")
+		})
+	})
+	stop := NewButton("Cancel alarm")
+	stop.OnClicked(func(*Button) {
+		if cancel != nil {
+			cancel()
+			alarm.SetText("alarm: cancelled")
+		}
+	})
+	box.Append(set)
+	box.Append(stop)
+
+	w.SetChild(box)
+	Main(w)
+}
+
+func main() {
+	mainScheduler()
+}
@@ -112,7 +112,30 @@ func mainSingle() {
 	Main(w)
 }
 
+func mainAnchored() {
+	Init()
+	w := NewWindow("test window with anchors", 640, 480, false)
+
+	// Resize the window to see the widgets follow the edges.
+	slab := NewSlab()
+	slab.AppendAnchored(NewLabel("top left"), Anchors{
+		Left: PinStart(8), Top: PinStart(8)})
+	slab.AppendAnchored(NewLabel("top right"), Anchors{
+		Right: PinEnd(-8), Top: PinStart(8)})
+	slab.AppendAnchored(NewButton("bottom right"), Anchors{
+		Right: PinEnd(-8), Bottom: PinEnd(-8)})
+	slab.AppendAnchored(NewLabel("centered"), Anchors{
+		CenterX: PinCenter(0), CenterY: PinCenter(0)})
+	// Stretched between the left and right edge, half the height.
+	slab.AppendAnchored(NewNote(), Anchors{
+		Left: PinStart(8), Right: PinEnd(-8), Top: PinStart(40), Height: 50})
+
+	w.SetChild(slab)
+	Main(w)
+}
+
 func main() {
 	// mainSingle()
+	// mainAnchored()
 	mainMultiple()
 }
@@ -0,0 +1,40 @@
+package main
+
+import "fmt"
+import . "github.com/bjorndm/golang-ui"
+
+func mainSplitter() {
+	Init()
+	w := NewWindow("test window with splitter", 800, 600, false)
+
+	// Master on the left, detail on the right.
+	master := NewVerticalBox()
+	for i := 1; i <= 10; i++ {
+		master.Append(NewLabel(fmt.Sprintf("Customer %d", i)))
+	}
+	detail := NewGrid()
+	detail.SetColumn(0, AutoColumn())
+	detail.AppendWithLabel("Name", NewEntry())
+	detail.AppendWithLabel("City", NewEntry())
+
+	// The log below can be resized as well.
+	log := NewJournal(true)
+	log.Append("Drag the dividers to resize, double click to collapse.")
+
+	sides := NewSplitter(false, master, detail)
+	sides.SetRatio(0.3)
+	sides.SetMinSizes(100, 200)
+	sides.OnChanged(func(s *Splitter) {
+		log.Append(fmt.Sprintf("ratio %.2f collapsed %t", s.Ratio(), s.Collapsed()))
+	})
+
+	split := NewSplitter(true, sides, log)
+	split.SetRatio(0.75)
+
+	w.SetChild(split)
+	Main(w)
+}
+
+func main() {
+	mainSplitter()
+}
@@ -0,0 +1,33 @@
+package main
+
+import . "github.com/bjorndm/golang-ui"
+
+func mainTray() {
+	Init()
+	w := NewWindow("test window with wrapping tray", 480, 480, false)
+
+	box := NewVerticalBox()
+	tags := []string{"invoice", "order", "customer", "supplier", "product",
+		"stock", "payment", "refund", "shipping", "discount", "tax", "report"}
+
+	// Resize the window to see the tags flow onto the next lines.
+	for _, align := range []StyleAlign{StyleAlignStart, StyleAlignCenter,
+		StyleAlignEnd, StyleAlignJustify} {
+		tray := NewTray()
+		tray.SetWrap(true)
+		tray.SetGaps(4, 4)
+		tray.SetLineAlign(align)
+		for _, tag := range tags {
+			tray.Append(NewButton(tag))
+		}
+		box.Append(NewLabel(align.String()))
+		box.Append(tray)
+	}
+
+	w.SetChild(box)
+	Main(w)
+}
+
+func main() {
+	mainTray()
+}
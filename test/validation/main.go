@@ -0,0 +1,76 @@
+package main
+
+import "fmt"
+import "regexp"
+import . "github.com/bjorndm/golang-ui"
+
+func newSignupForm() *Grid {
+	grid := NewGrid()
+
+	name := NewEntry()
+	name.AddValidator(Required("Please enter your name."))
+	grid.AppendWithLabel("Name", name)
+
+	mail := NewEntry()
+	mail.AddValidator(Required(""), MatchRegexp(regexp.MustCompile(`^[^@ ]+@[^@ ]+$`), "Please enter an e-mail address."))
+	mail.SetValidationMode(ValidateOnSubmit)
+	grid.AppendWithLabel("E-mail", mail)
+
+	age := NewEntry()
+	age.AddValidator(InRange(18, 130, ""))
+	grid.AppendWithLabel("Age", age)
+
+	country := NewDropdown()
+	country.Append("Belgium")
+	country.Append("Japan")
+	country.Append("Other")
+	country.SetSelected(-1)
+	country.AddValidator(Required("Please select a country."))
+	grid.AppendWithLabel("Country", country)
+
+	about := NewNote()
+	about.AddValidator(Check(func(value string) bool {
+		return len(value) <= 100
+	}, "At most 100 characters please."))
+	grid.AppendWithLabel("About", about)
+
+	terms := NewCheckbox("I accept the terms")
+	terms.AddValidator(Required("You must accept the terms."))
+	terms.SetValidationMode(ValidateOnSubmit)
+	grid.AppendWithLabel("", terms)
+	return grid
+}
+
+func mainValidation() {
+	Init()
+	w := NewWindow("test window with validation", 640, 480, false)
+
+	box := NewVerticalBox()
+	form := newSignupForm()
+	box.Append(form)
+
+	submit := NewButton("Submit")
+	submit.OnClicked(func(*Button) {
+		if err := NewForm(form).Validate(); err != nil {
+			fmt.Printf("invalid:\n%v\n", err)
+			return
+		}
+		fmt.Printf("valid\n")
+	})
+	box.Append(submit)
+
+	dialogButton := NewButton("Dialog")
+	dialogButton.OnClicked(func(*Button) {
+		ShowDialog(w, "Sign up", newSignupForm(), func(res DialogResult) {
+			fmt.Printf("dialog result: %s\n", res)
+		}).AddButton("OK", DialogResultOK).AddButton("Cancel", DialogResultCancel)
+	})
+	box.Append(dialogButton)
+
+	w.SetChild(box)
+	Main(w)
+}
+
+func main() {
+	mainValidation()
+}
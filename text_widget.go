@@ -15,6 +15,7 @@ func (t *TextWidget) SetText(text string) {
 	minw := t.Style().Size.Width.Int()
 	minh := t.Style().Size.Height.Int()
 	t.LayoutWidget(minh, minw)
+	Invalidate(t)
 }
 
 func NewTextWidget(text string) *TextWidget {
@@ -47,13 +48,9 @@ func (t TextWidget) DrawWidget(dst *Graphic) {
 		col = theme.Disable.Color.RGBA()
 	}
 
-	if t.Style().Align == StyleAlignMiddle {
-		dx = dx + t.width/2
-	} else if t.Style().Align == StyleAlignRight {
-		dx = dx + t.width
-	}
-
-	TextDrawOffset(dst, t.text, face, dx, dy, col)
+	align := t.Style().Align.Directed(t.RightToLeft())
+	dy += face.Metrics().Ascent.Round()
+	TextDrawAligned(dst, t.text, face, dx, dy, t.width, align, col)
 	t.DrawDebug(dst, "TXT")
 }
 
@@ -80,6 +77,7 @@ func (t *IconTextWidget) SetIcon(icon string) {
 	minw := t.Style().Size.Width.Int()
 	minh := t.Style().Size.Height.Int()
 	t.LayoutWidget(minh, minw)
+	Invalidate(t)
 }
 
 func (t *IconTextWidget) LayoutWidget(parentWidth, parentHeight int) {
@@ -111,11 +109,19 @@ func (t IconTextWidget) DrawWidget(dst *Graphic) {
 		col = theme.Disable.Color.RGBA()
 	}
 
-	dxt := dx
+	dxi, dxt := dx, dx
 	if t.icon != "" {
-		iconAtlas.DrawSprite(dst, dx, dy, t.height, t.height, t.icon)
 		dxt += t.height + margin
 	}
+	// From right to left, the icon is behind the text on the right.
+	if t.RightToLeft() {
+		tw, _ := oneLineTextSize(face, t.text)
+		dxi = dx + t.width - t.height
+		dxt = dx + t.width - (dxt - dx) - tw
+	}
+	if t.icon != "" {
+		iconAtlas.DrawSprite(dst, dxi, dy, t.height, t.height, t.icon)
+	}
 
 	TextDrawOffset(dst, t.text, face, dxt, dy, col)
 	t.DrawDebug(dst, "TXT")
@@ -185,7 +185,11 @@ func (s *FontStyle) UnmarshalJSON(buf []byte) error {
 	if s.Family == "" || s.Family == "default" {
 		s.Font = defaultFont
 	} else {
-		font := loadResourceFontOptional("resource/font/" + s.Family + ".ttf")
+		var font *Font
+		// In golden image mode all text uses the default font, see goldenFontName.
+		if goldenDir() == "" {
+			font = loadResourceFontOptional("resource/font/" + s.Family + ".ttf")
+		}
 		if font == nil {
 			s.Font = defaultFont
 		} else {
@@ -1,9 +1,14 @@
 package ui
 
 // A Tray is a container with a horizontal widget layout.
+// In wrapping mode, the widgets that don't fit flow onto the next line.
 type Tray struct {
 	BasicContainer
-	padded bool
+	padded    bool
+	wrap      bool       // wrap is set for the wrapping mode.
+	hgap      int        // hgap is the space between the widgets on a line.
+	vgap      int        // vgap is the space between the lines.
+	lineAlign StyleAlign // lineAlign is the alignment of the lines.
 }
 
 func (b *Tray) Destroy() {
@@ -44,6 +49,45 @@ func (b *Tray) SetPadded(padded bool) {
 	b.padded = padded
 }
 
+// Wrap returns whether the tray is in wrapping mode.
+func (b Tray) Wrap() bool {
+	return b.wrap
+}
+
+// SetWrap sets the wrapping mode. In wrapping mode, the widgets that don't
+// fit in the available width flow onto the next line, in stead of being
+// clipped.
+func (b *Tray) SetWrap(wrap bool) {
+	b.wrap = wrap
+	NeedLayout(b)
+}
+
+// SetGaps sets the horizontal space between the widgets, and the vertical
+// space between the lines in wrapping mode.
+func (b *Tray) SetGaps(horizontal, vertical int) {
+	b.hgap = horizontal
+	b.vgap = vertical
+	NeedLayout(b)
+}
+
+// Gaps returns the horizontal and vertical gaps.
+func (b Tray) Gaps() (horizontal, vertical int) {
+	return b.hgap, b.vgap
+}
+
+// SetLineAlign sets the alignment of the lines in wrapping mode. With
+// StyleAlignJustify the space is spread between the widgets of all lines
+// but the last one.
+func (b *Tray) SetLineAlign(align StyleAlign) {
+	b.lineAlign = align
+	NeedLayout(b)
+}
+
+// LineAlign returns the alignment of the lines in wrapping mode.
+func (b Tray) LineAlign() StyleAlign {
+	return b.lineAlign
+}
+
 func newTray() *Tray {
 	b := &Tray{}
 	b.controls = []Control{}
@@ -60,6 +104,10 @@ func NewTray() *Tray {
 // of the parent, but horizontally they are not constrained.
 func (b *Tray) LayoutWidget(width, height int) {
 	dprintln("Box.LayoutWidget", len(b.controls), width, height)
+	if b.wrap {
+		b.layoutWrapped(width, height)
+		return
+	}
 
 	margin := b.Style().Margin.Int()
 	x := margin
@@ -69,6 +117,7 @@ func (b *Tray) LayoutWidget(width, height int) {
 	availableWidth := width - margin*2
 
 	highest := 0
+	placed := 0
 	b.width = margin * 2
 	for _, child := range b.controls {
 		if child.Hidden() {
@@ -76,6 +125,11 @@ func (b *Tray) LayoutWidget(width, height int) {
 		}
 		// No limits on the width in a tray.
 		child.LayoutWidget(availableWidth, availableHeight)
+		if placed > 0 {
+			x += b.hgap
+			b.width += b.hgap
+		}
+		placed++
 		child.MoveWidget(x, y)
 		childWidth, childHeight := child.WidgetSize()
 		x += childWidth
@@ -87,6 +141,7 @@ func (b *Tray) LayoutWidget(width, height int) {
 
 	// Take on the height of the heighest child/ plus margins.
 	b.height = highest + margin*2
+	b.mirrorChildren(b.width)
 
 	// Finally clip to desired size.
 	b.ClipTo(width, height)
@@ -95,6 +150,78 @@ func (b *Tray) LayoutWidget(width, height int) {
 	dprintln("Tray.LayoutWidget done", len(b.controls), b.width, b.height)
 }
 
+// trayLine is a line of widgets of a wrapping tray.
+type trayLine struct {
+	controls []Control
+	width    int
+	height   int
+}
+
+// layoutWrapped places the widgets next to each other, and starts a new
+// line below when the next widget does not fit in the available width.
+func (b *Tray) layoutWrapped(width, height int) {
+	margin := b.Style().Margin.Int()
+	availableWidth := width - margin*2
+	availableHeight := height - margin*2
+
+	var lines []*trayLine
+	var line *trayLine
+	for _, child := range b.controls {
+		if child.Hidden() {
+			continue
+		}
+		child.LayoutWidget(availableWidth, availableHeight)
+		childWidth, childHeight := child.WidgetSize()
+		if line == nil || (layoutBounded(availableWidth) &&
+			line.width+b.hgap+childWidth > availableWidth) {
+			line = &trayLine{}
+			lines = append(lines, line)
+		} else {
+			line.width += b.hgap
+		}
+		line.controls = append(line.controls, child)
+		line.width += childWidth
+		line.height = max(line.height, childHeight)
+	}
+
+	widest := 0
+	for _, line := range lines {
+		widest = max(widest, line.width)
+	}
+	lineSpace := widest
+	if layoutBounded(availableWidth) && b.lineAlign != StyleAlignDefault &&
+		b.lineAlign != StyleAlignLeft {
+		lineSpace = availableWidth
+	}
+
+	y := margin
+	for i, line := range lines {
+		x := b.lineAlign.Position(margin, line.width, lineSpace)
+		gap := b.hgap
+		if b.lineAlign == StyleAlignJustify && i < len(lines)-1 && len(line.controls) > 1 {
+			gap += (lineSpace - line.width) / (len(line.controls) - 1)
+		}
+		for _, child := range line.controls {
+			child.MoveWidget(x, y)
+			childWidth, _ := child.WidgetSize()
+			x += childWidth + gap
+		}
+		y += line.height + b.vgap
+	}
+
+	b.width = lineSpace + margin*2
+	b.height = margin * 2
+	for i, line := range lines {
+		if i > 0 {
+			b.height += b.vgap
+		}
+		b.height += line.height
+	}
+	b.mirrorChildren(b.width)
+	b.ClipTo(width, height)
+	b.BasicContainer.UpdateOrdered()
+}
+
 func (b Tray) DrawWidget(g *Graphic) {
 	dx, dy := b.WidgetAbsolute()
 
@@ -0,0 +1,56 @@
+package ui
+
+import "testing"
+
+func TestTrayWrapped(t *testing.T) {
+	sizes := [][2]int{{20, 10}, {20, 10}, {20, 10}, {10, 15}}
+	tests := []struct {
+		name  string
+		align StyleAlign
+		width int
+		want  [][2]int
+		size  [2]int
+	}{
+		{"left", StyleAlignDefault, 50,
+			[][2]int{{0, 0}, {25, 0}, {0, 13}, {25, 13}}, [2]int{45, 28}},
+		{"right", StyleAlignRight, 50,
+			[][2]int{{5, 0}, {30, 0}, {15, 13}, {40, 13}}, [2]int{50, 28}},
+		{"middle", StyleAlignMiddle, 50,
+			[][2]int{{2, 0}, {27, 0}, {7, 13}, {32, 13}}, [2]int{50, 28}},
+		{"justify", StyleAlignJustify, 50,
+			[][2]int{{0, 0}, {30, 0}, {0, 13}, {25, 13}}, [2]int{50, 28}},
+		{"justify three lines", StyleAlignJustify, 30,
+			[][2]int{{0, 0}, {0, 13}, {0, 26}, {0, 39}}, [2]int{30, 54}},
+		{"unbounded", StyleAlignJustify, LayoutUnlimited,
+			[][2]int{{0, 0}, {25, 0}, {50, 0}, {75, 0}}, [2]int{85, 15}},
+	}
+	for _, tt := range tests {
+		tray := NewTray()
+		tray.SetWrap(true)
+		tray.SetGaps(5, 3)
+		tray.SetLineAlign(tt.align)
+		var children []Control
+		for _, size := range sizes {
+			child := newLayoutRecorder(size[0], size[1])
+			tray.Append(child)
+			children = append(children, child)
+		}
+		margin := tray.Style().Margin.Int()
+		width := tt.width
+		if layoutBounded(width) {
+			width += margin * 2
+		}
+		tray.LayoutWidget(width, LayoutUnlimited)
+		for i, child := range children {
+			x, y := child.WidgetAt()
+			want := [2]int{tt.want[i][0] + margin, tt.want[i][1] + margin}
+			if [2]int{x, y} != want {
+				t.Errorf("%s: child %d at %d,%d, want %v", tt.name, i, x, y, want)
+			}
+		}
+		w, h := tray.WidgetSize()
+		if want := [2]int{tt.size[0] + margin*2, tt.size[1] + margin*2}; [2]int{w, h} != want {
+			t.Errorf("%s: size %dx%d, want %v", tt.name, w, h, want)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// package uitest contains a test driver for golang-ui that does not open a
+// window.
+//
+// A Driver builds a Window without calling ebiten.RunGame, sends synthetic
+// events straight to Window.HandleWidget and lays out the widgets, so
+// go test can check the values, focus, sizes and positions of the widgets.
+// No window is opened, but ebiten initializes GLFW when it is imported,
+// before any code of this package runs, and that fails without a display on
+// Linux and BSD. So the tests can't run on a machine without a display; on
+// such a CI server run them with a virtual X server, as test/ci.sh does with
+// xvfb-run.
+//
+//	func TestEntry(t *testing.T) {
+//		d := uitest.New(640, 480)
+//		entry := ui.NewEntry()
+//		d.SetChild(entry)
+//		d.ClickControl(entry)
+//		d.Type("hello")
+//		if entry.Text() != "hello" {
+//			t.Errorf("entry: %q", entry.Text())
+//		}
+//	}
+package uitest
+
+import "image"
+import "io"
+import "sync"
+import "time"
+
+import "github.com/bjorndm/golang-ui"
+
+var initOnce sync.Once
+
+// Tick is the duration of one tick of the Driver.
+const Tick = time.Second / 60
+
+// Driver drives a Window without ebiten.RunGame.
+type Driver struct {
+	Window    *ui.Window
+	width     int
+	height    int
+	mouseX    int
+	mouseY    int
+	modifiers ui.EventModifiers
+}
+
+// New returns a Driver with a new Window of the given size.
+// The library is initialized with ui.TestInit if this was not done yet.
+func New(width, height int) *Driver {
+	initOnce.Do(ui.TestInit)
+	d := &Driver{width: width, height: height}
+	d.Window = ui.NewWindow("uitest", width, height, false)
+	return d
+}
+
+// NewWithMenuBar returns a Driver with a new Window of the given size that
+// has a menu bar.
+func NewWithMenuBar(width, height int) *Driver {
+	initOnce.Do(ui.TestInit)
+	d := &Driver{width: width, height: height}
+	d.Window = ui.NewWindow("uitest", width, height, true)
+	return d
+}
+
+// SetChild sets the child of the window and lays it out.
+func (d *Driver) SetChild(c ui.Control) {
+	d.Window.SetChild(c)
+	d.Layout()
+}
+
+// Layout lays out the window and all widgets in it.
+func (d *Driver) Layout() {
+	d.Window.Relayout()
+	d.Window.LayoutWidget(d.width, d.height)
+}
+
+// Resize changes the size of the window and lays it out again.
+func (d *Driver) Resize(width, height int) {
+	d.width, d.height = width, height
+	d.Layout()
+}
+
+// Size returns the size of the window.
+func (d *Driver) Size() (width, height int) {
+	return d.width, d.height
+}
+
+// SetModifiers sets the keyboard modifiers of the following events.
+func (d *Driver) SetModifiers(modifiers ui.EventModifiers) {
+	d.modifiers = modifiers
+}
+
+func (d *Driver) basic() ui.BasicEvent {
+	return ui.BasicEvent{EventOrigin: d.Window, EventModifiers: d.modifiers}
+}
+
+func (d *Driver) mouse(x, y int) ui.MouseEvent {
+	return ui.MouseEvent{BasicEvent: d.basic(), X: x, Y: y}
+}
+
+// Send sends the event to the window, and lays out the window if needed.
+func (d *Driver) Send(e ui.Event) {
+	d.Window.HandleWidget(e)
+	d.Window.Layout(d.width, d.height)
+}
+
+// Tick sends n update events, as would happen in n ticks.
+func (d *Driver) Tick(n int) {
+	for i := 0; i < n; i++ {
+		d.Send(&ui.UpdateEvent{BasicEvent: d.basic(), Duration: Tick})
+	}
+}
+
+// MoveMouse moves the mouse to x and y.
+func (d *Driver) MoveMouse(x, y int) {
+	if x == d.mouseX && y == d.mouseY {
+		return
+	}
+	me := &ui.MouseMoveEvent{MouseEvent: d.mouse(x, y)}
+	me.MoveX = x - d.mouseX
+	me.MoveY = y - d.mouseY
+	d.mouseX, d.mouseY = x, y
+	d.Send(me)
+}
+
+// PressMouse moves the mouse to x, y and presses the button there.
+func (d *Driver) PressMouse(x, y int, button ui.MouseButton) {
+	d.MoveMouse(x, y)
+	d.Send(&ui.MouseClickEvent{MouseEvent: d.mouse(x, y), Button: button})
+}
+
+// ReleaseMouse moves the mouse to x, y and releases the button there.
+func (d *Driver) ReleaseMouse(x, y int, button ui.MouseButton) {
+	d.MoveMouse(x, y)
+	d.Send(&ui.MouseReleaseEvent{MouseEvent: d.mouse(x, y), Button: button, Duration: Tick})
+}
+
+// Click clicks the left mouse button at x and y.
+func (d *Driver) Click(x, y int) {
+	d.PressMouse(x, y, ui.MouseButtonLeft)
+	d.ReleaseMouse(x, y, ui.MouseButtonLeft)
+}
+
+// ClickControl clicks the left mouse button in the center of c.
+func (d *Driver) ClickControl(c ui.Control) {
+	x, y := Center(c)
+	d.Click(x, y)
+}
+
+// Drag presses the left mouse button at x1, y1, moves to x2, y2 and
+// releases the button there.
+func (d *Driver) Drag(x1, y1, x2, y2 int) {
+	d.PressMouse(x1, y1, ui.MouseButtonLeft)
+	d.ReleaseMouse(x2, y2, ui.MouseButtonLeft)
+}
+
+// Wheel turns the mouse wheel at x and y.
+func (d *Driver) Wheel(x, y int, wheelX, wheelY float64) {
+	d.MoveMouse(x, y)
+	d.Send(&ui.WheelEvent{MouseEvent: d.mouse(x, y), WheelX: wheelX, WheelY: wheelY})
+}
+
+// KeyDown sends a key press event for the key.
+func (d *Driver) KeyDown(key ui.Key) {
+	d.Send(&ui.KeyPressEvent{KeyEvent: ui.KeyEvent{BasicEvent: d.basic(), Key: key}})
+}
+
+// KeyUp sends a key release event for the key.
+func (d *Driver) KeyUp(key ui.Key) {
+	d.Send(&ui.KeyReleaseEvent{KeyEvent: ui.KeyEvent{BasicEvent: d.basic(), Key: key}})
+}
+
+// Press presses and releases the key.
+func (d *Driver) Press(key ui.Key) {
+	d.KeyDown(key)
+	d.KeyUp(key)
+}
+
+// PressWith presses and releases the key with the given modifiers.
+func (d *Driver) PressWith(modifiers ui.EventModifiers, key ui.Key) {
+	old := d.modifiers
+	d.modifiers = modifiers
+	d.Press(key)
+	d.modifiers = old
+}
+
+// Type sends the text as character input.
+func (d *Driver) Type(text string) {
+	if text == "" {
+		return
+	}
+	d.Send(&ui.CharEvent{BasicEvent: d.basic(), Runes: []rune(text)})
+}
+
+// PressGamepad presses and releases the button of a gamepad with the
+// standard layout.
+func (d *Driver) PressGamepad(button ui.StandardGamepadButton) {
+	ge := ui.GamepadEvent{BasicEvent: d.basic(), Standard: true}
+	be := ui.GamepadButtonEvent{GamepadEvent: ge, Button: ui.GamepadButton(button), StandardButton: button}
+	d.Send(&ui.GamepadButtonPressEvent{GamepadButtonEvent: be})
+	d.Send(&ui.GamepadButtonReleaseEvent{GamepadButtonEvent: be, Duration: Tick})
+}
+
+// Replay replays a recording made with Window.StartRecording, sending the
+// events of each recorded tick after an update event for that tick.
+// It returns the error that stopped the replay, if any.
+func (d *Driver) Replay(rd io.Reader) error {
+	p := ui.NewEventReplayer(rd)
+	for tick := int64(1); !p.Done(); tick++ {
+		d.Tick(1)
+		p.Replay(tick, d.Window, d.Send)
+	}
+	return p.Err()
+}
+
+// Render draws the window with the software renderer and returns the image.
+func (d *Driver) Render() *image.RGBA {
+	return ui.RenderSoftware(d.Window, d.width, d.height)
+}
+
+// CheckGolden renders the window and compares it with the golden PNG image
+// in the file name, see ui.CheckGolden. If update is true, the golden image
+// is written in stead.
+func (d *Driver) CheckGolden(name string, update bool) error {
+	return ui.CheckGolden(d.Render(), name, ui.GoldenTolerance, update)
+}
+
+// Accessibility returns the accessibility tree of the window.
+func (d *Driver) Accessibility() *ui.AccessibilityNode {
+	return ui.AccessibilityTree(d.Window)
+}
+
+// Focused returns the innermost focused control of the window.
+func (d *Driver) Focused() ui.Control {
+	return ui.FocusedControl(d.Window)
+}
+
+// Bounds returns the absolute position and the size of c.
+func Bounds(c ui.Control) (x, y, width, height int) {
+	x, y = ui.ControlAbsolute(c)
+	width, height = c.WidgetSize()
+	return x, y, width, height
+}
+
+// Center returns the absolute position of the center of c.
+func Center(c ui.Control) (x, y int) {
+	x, y, w, h := Bounds(c)
+	return x + w/2, y + h/2
+}
@@ -0,0 +1,263 @@
+package uitest
+
+import "testing"
+
+import "github.com/bjorndm/golang-ui"
+
+func TestTabIntoEntry(t *testing.T) {
+	d := New(640, 480)
+	box := ui.NewVerticalBox()
+	button := ui.NewButton("button")
+	entry := ui.NewEntry()
+	box.Append(button)
+	box.Append(entry)
+	d.SetChild(box)
+
+	d.Press(ui.KeyTab)
+	if d.Focused() != ui.Control(button) {
+		t.Fatalf("focused %T, want the button", d.Focused())
+	}
+	d.Press(ui.KeyTab)
+	if d.Focused() != ui.Control(entry) {
+		t.Fatalf("focused %T, want the entry", d.Focused())
+	}
+	d.Type("hello")
+	if entry.Text() != "hello" {
+		t.Errorf("entry text %q, want %q", entry.Text(), "hello")
+	}
+}
+
+func TestEntry(t *testing.T) {
+	d := New(640, 480)
+	entry := ui.NewEntry()
+	d.SetChild(entry)
+
+	d.ClickControl(entry)
+	d.Type("helo")
+	d.Press(ui.KeyArrowLeft)
+	d.Type("l")
+	d.Press(ui.KeyEnd)
+	d.Type("!")
+	if entry.Text() != "hello!" {
+		t.Errorf("entry text %q, want %q", entry.Text(), "hello!")
+	}
+	d.Press(ui.KeyBackspace)
+	d.Press(ui.KeyHome)
+	d.Press(ui.KeyDelete)
+	if entry.Text() != "ello" {
+		t.Errorf("entry text %q, want %q", entry.Text(), "ello")
+	}
+}
+
+func TestDropdown(t *testing.T) {
+	d := New(640, 480)
+	dropdown := ui.NewDropdown()
+	for _, item := range []string{"one", "two", "three"} {
+		dropdown.Append(item)
+	}
+	selected := -1
+	dropdown.OnSelected(func(dd *ui.Dropdown) {
+		selected = dd.Selected()
+	})
+	d.SetChild(dropdown)
+
+	d.ClickControl(dropdown)
+	d.Press(ui.KeyArrowDown)
+	d.Press(ui.KeyArrowDown)
+	d.Press(ui.KeyEnter)
+	if selected != 2 || dropdown.Text() != "three" {
+		t.Errorf("selected %d %q, want 2 %q", selected, dropdown.Text(), "three")
+	}
+}
+
+// tableModel is a table model with a text and a boolean column.
+type tableModel []ui.Row
+
+func (m tableModel) NumRows() int {
+	return len(m)
+}
+
+func (m tableModel) FetchRow(index int) ui.Row {
+	if index < 0 || index >= len(m) {
+		return nil
+	}
+	return m[index]
+}
+
+func (m tableModel) UpdateRow(index int, row ui.Row) {
+	if index >= 0 && index < len(m) {
+		m[index] = row
+	}
+}
+
+func TestTable(t *testing.T) {
+	d := New(640, 480)
+	model := tableModel{}
+	for _, name := range []string{"apple", "banana", "cherry"} {
+		model = append(model, ui.NewRow(ui.NewValue(name), ui.NewValue(false)))
+	}
+	table := ui.NewTable(model)
+	table.AppendColumn(ui.NewTextColumn("Name", 0))
+	table.AppendColumn(ui.NewCheckboxColumn("Done", 1))
+	table.SetHeaderVisible(false)
+	clickedColumn, clickedRow := -1, -1
+	table.OnClicked(func(_ *ui.Table, column, row int) {
+		clickedColumn, clickedRow = column, row
+	})
+	d.SetChild(table)
+
+	// Click the checkbox of the second row.
+	x, y, w, _ := Bounds(table.Column(1))
+	rowHeight := table.RowHeight()
+	d.Click(x+w/2, y+rowHeight+rowHeight/2)
+	if clickedColumn != 1 || clickedRow != 1 {
+		t.Errorf("clicked column %d row %d, want 1 1", clickedColumn, clickedRow)
+	}
+	for i, want := range []bool{false, true, false} {
+		if got := model[i].Value(1); got != want {
+			t.Errorf("row %d: %v, want %v", i, got, want)
+		}
+	}
+}
+
+// findButton returns the button with the text below c, or nil.
+func findButton(c ui.Control, text string) *ui.Button {
+	if button, ok := c.(*ui.Button); ok && button.Text() == text {
+		return button
+	}
+	for _, child := range ui.ControlChildren(c) {
+		if button := findButton(child, text); button != nil {
+			return button
+		}
+	}
+	return nil
+}
+
+func TestDialog(t *testing.T) {
+	d := New(640, 480)
+	d.SetChild(ui.NewLabel("window"))
+
+	result := ui.DialogResultNone
+	entry := ui.NewEntry()
+	dialog := ui.ShowDialog(d.Window, "dialog", entry, func(r ui.DialogResult) {
+		result = r
+	})
+	dialog.AddButton("OK", ui.DialogResultOK).AddButton("Cancel", ui.DialogResultCancel)
+	d.Layout()
+
+	d.ClickControl(entry)
+	d.Type("name")
+	ok := findButton(dialog.Pane, "OK")
+	if ok == nil {
+		t.Fatal("no OK button in the dialog")
+	}
+	d.ClickControl(ok)
+	if result != ui.DialogResultOK {
+		t.Errorf("result %q, want %q", result, ui.DialogResultOK)
+	}
+	if !dialog.Pane.Hidden() {
+		t.Error("the dialog is still shown")
+	}
+	if entry.Text() != "name" {
+		t.Errorf("entry text %q, want %q", entry.Text(), "name")
+	}
+}
+
+func TestRightToLeft(t *testing.T) {
+	d := New(640, 480)
+	d.Window.SetLayoutDirection(ui.LayoutRightToLeft)
+	first, second := ui.NewLabel("first"), ui.NewLabel("second")
+	splitter := ui.NewSplitter(false, first, second)
+	navigation := ui.NewLabel("navigation")
+	dock := ui.NewDock()
+	dock.Append(navigation, ui.DockLeft)
+	dock.Append(splitter, ui.DockCenter)
+	d.SetChild(dock)
+
+	nx, _, nw, _ := Bounds(navigation)
+	sx, _, _, _ := Bounds(splitter)
+	if nx <= sx || nx+nw > 640 {
+		t.Errorf("navigation at %d width %d, want it on the right of %d", nx, nw, sx)
+	}
+	fx, _, _, _ := Bounds(first)
+	ex, _, _, _ := Bounds(second)
+	if fx <= ex {
+		t.Errorf("first at %d, second at %d, want the first on the right", fx, ex)
+	}
+}
+
+func TestSplitterDoubleClick(t *testing.T) {
+	d := New(640, 480)
+	first := ui.NewLabel("first")
+	splitter := ui.NewSplitter(false, first, ui.NewLabel("second"))
+	d.SetChild(splitter)
+
+	// The divider is right of the first widget.
+	x, y, w, h := Bounds(first)
+	divider := x + w + 3
+	d.Click(divider, y+h/2)
+	d.Click(divider, y+h/2)
+	if !splitter.Collapsed() {
+		t.Fatal("a double click did not collapse the splitter")
+	}
+
+	// The divider is at the start of the collapsed splitter.
+	sx, _, _, _ := Bounds(splitter)
+	divider = sx + 3
+	d.Click(divider, y+h/2)
+	d.Tick(30)
+	d.Click(divider, y+h/2)
+	if !splitter.Collapsed() {
+		t.Error("two slow clicks restored the splitter")
+	}
+	d.Click(divider, y+h/2)
+	if splitter.Collapsed() {
+		t.Error("a double click did not restore the splitter")
+	}
+}
+
+func TestValidateOnChange(t *testing.T) {
+	d := New(640, 480)
+	entry := ui.NewEntry()
+	entry.AddValidator(ui.Required(""))
+	d.SetChild(entry)
+
+	d.ClickControl(entry)
+	d.Press(ui.KeyArrowLeft)
+	if err := entry.ValidationError(); err != nil {
+		t.Errorf("untouched entry: %v", err)
+	}
+	d.Type("a")
+	if err := entry.ValidationError(); err != nil {
+		t.Errorf("filled entry: %v", err)
+	}
+	d.Press(ui.KeyBackspace)
+	if entry.ValidationError() == nil {
+		t.Error("emptied entry: no error")
+	}
+}
+
+func TestTabInNote(t *testing.T) {
+	d := New(640, 480)
+	box := ui.NewVerticalBox()
+	button := ui.NewButton("button")
+	note := ui.NewNote()
+	box.Append(button)
+	box.Append(note)
+	d.SetChild(box)
+
+	d.ClickControl(note)
+	d.Type("a")
+	d.Press(ui.KeyTab)
+	d.Type("b")
+	if note.Text() != "a\tb" {
+		t.Errorf("note text %q, want %q", note.Text(), "a\tb")
+	}
+	if d.Focused() != ui.Control(note) {
+		t.Fatalf("focused %T after Tab, want the note", d.Focused())
+	}
+	d.PressWith(ui.EventModifiers{Control: true}, ui.KeyTab)
+	if d.Focused() != ui.Control(button) {
+		t.Errorf("focused %T after Control+Tab, want the button", d.Focused())
+	}
+}
@@ -0,0 +1,291 @@
+package ui
+
+import "errors"
+import "fmt"
+import "regexp"
+import "strconv"
+import "strings"
+
+// Validator checks the value of a widget. It returns an error with a message
+// for the user if the value is invalid, or nil if it is valid. Any function
+// with this signature can be used as a custom validator.
+//
+// The value of an Entry or Note is its text, and the value of a Dropdown is
+// the text of the selected item. Like in a HTML form, the value of a
+// Checkbox is its text if it is checked, and empty if it is not.
+type Validator func(value string) error
+
+// Required returns a validator that rejects empty values. For a Checkbox
+// this means that it must be checked. The message may be empty for a
+// default message.
+func Required(message string) Validator {
+	if message == "" {
+		message = "This field is required."
+	}
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// MatchRegexp returns a validator that rejects values that don't match re.
+// Empty values are accepted, use Required to reject those.
+func MatchRegexp(re *regexp.Regexp, message string) Validator {
+	if message == "" {
+		message = "The format is not valid."
+	}
+	return func(value string) error {
+		if value != "" && !re.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// InRange returns a validator that rejects values that are not a number
+// between min and max, inclusive. Empty values are accepted, use Required
+// to reject those.
+func InRange(min, max float64, message string) Validator {
+	if message == "" {
+		message = fmt.Sprintf("Must be a number from %g to %g.", min, max)
+	}
+	return func(value string) error {
+		if value == "" {
+			return nil
+		}
+		num, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || num < min || num > max {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// Check returns a validator that rejects the values for which valid returns
+// false.
+func Check(valid func(value string) bool, message string) Validator {
+	return func(value string) error {
+		if !valid(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// ValidationMode is when the validators of a widget run.
+type ValidationMode int
+
+const (
+	// ValidateOnChange validates each time the user changes the widget. A
+	// widget that the user did not change yet is not validated.
+	ValidateOnChange ValidationMode = iota
+	// ValidateOnSubmit only validates when Validate is called, for example
+	// by Form.Validate. Once the widget is invalid, it is validated on each
+	// change again, so the error goes away as soon as it is fixed.
+	ValidateOnSubmit
+)
+
+// Validation is the validation state of a widget. It is embedded in the
+// widgets that can be validated.
+type Validation struct {
+	validators []Validator
+	mode       ValidationMode
+	err        error
+	validated  bool   // validated is set once the value was validated.
+	last       string // last is the value that was validated.
+	dirty      bool   // dirty is set once the user changed the value.
+}
+
+// AddValidator adds validators to the widget. They run in order, and the
+// first error is the validation error of the widget.
+func (v *Validation) AddValidator(validators ...Validator) {
+	v.validators = append(v.validators, validators...)
+}
+
+// SetValidationMode sets when the validators run.
+func (v *Validation) SetValidationMode(mode ValidationMode) {
+	v.mode = mode
+}
+
+// ValidationError returns the error of the last validation, or nil if the
+// widget was valid or not validated yet.
+func (v Validation) ValidationError() error {
+	return v.err
+}
+
+// ClearValidation clears the validation error. The widget is validated on
+// change again once the user changes it.
+func (v *Validation) ClearValidation() {
+	v.err = nil
+	v.validated = false
+	v.dirty = false
+}
+
+func (v *Validation) validate(value string) error {
+	v.err = nil
+	v.validated = true
+	v.last = value
+	for _, validator := range v.validators {
+		if err := validator(value); err != nil {
+			v.err = err
+			break
+		}
+	}
+	return v.err
+}
+
+// changed is called after each event with the value before and after it.
+// It validates the value if the user changed it and the mode calls for it.
+func (v *Validation) changed(before, value string) {
+	if before != value {
+		v.dirty = true
+	}
+	if len(v.validators) == 0 || !v.dirty || (v.validated && v.last == value) {
+		return
+	}
+	if v.mode == ValidateOnChange || v.err != nil {
+		v.validate(value)
+	}
+}
+
+// drawError draws the error frame around the widget if it is invalid.
+func (v Validation) drawError(dst *Graphic, x, y, w, h int) {
+	if v.err != nil {
+		StrokeRect(dst, x, y, w, h, 2, theme.Error.Color.RGBA())
+	}
+}
+
+// Validatable is implemented by widgets that can be validated.
+type Validatable interface {
+	Control
+	// Validate runs the validators of the widget and returns the error.
+	Validate() error
+	// ValidationError returns the error of the last validation.
+	ValidationError() error
+}
+
+// Validate validates the text of the entry.
+func (e *Entry) Validate() error {
+	return e.validate(e.Text())
+}
+
+// Validate validates the text of the note.
+func (e *Note) Validate() error {
+	return e.validate(e.Text())
+}
+
+// Validate validates the text of the selected item.
+func (d *Dropdown) Validate() error {
+	return d.validate(d.Text())
+}
+
+// Validate validates the checkbox.
+func (b *Checkbox) Validate() error {
+	return b.validate(b.validationValue())
+}
+
+func (b *Checkbox) validationValue() string {
+	if b.checked {
+		return b.text
+	}
+	return ""
+}
+
+// ValidationError is the error of a widget in a Form.
+type ValidationError struct {
+	Control Control
+	Err     error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Form validates the widgets below a root widget together.
+type Form struct {
+	root Control
+}
+
+// NewForm returns a form for the validatable widgets below root.
+func NewForm(root Control) *Form {
+	return &Form{root: root}
+}
+
+// Fields returns the visible and enabled validatable widgets of the form, in
+// tree order.
+func (f *Form) Fields() []Validatable {
+	var fields []Validatable
+	var walk func(c Control)
+	walk = func(c Control) {
+		if c == nil || c.Hidden() || !c.Enabled() {
+			return
+		}
+		if v, ok := c.(Validatable); ok {
+			fields = append(fields, v)
+		}
+		for _, child := range ControlChildren(c) {
+			walk(child)
+		}
+	}
+	walk(f.root)
+	return fields
+}
+
+// Validate validates all fields of the form. It returns nil if they are all
+// valid. Otherwise, it focuses the first invalid field, and returns the
+// errors joined, each one a *ValidationError.
+func (f *Form) Validate() error {
+	var errs []error
+	for _, field := range f.Fields() {
+		if err := field.Validate(); err != nil {
+			errs = append(errs, &ValidationError{Control: field, Err: err})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	FocusControl(errs[0].(*ValidationError).Control)
+	return errors.Join(errs...)
+}
+
+// Valid returns whether all fields of the form were valid the last time
+// they were validated. It does not run the validators.
+func (f *Form) Valid() bool {
+	for _, field := range f.Fields() {
+		if field.ValidationError() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// drawValidationMessage draws the validation error of the focused widget
+// of the window below it, like a tooltip.
+func (w *Window) drawValidationMessage(screen *Graphic) {
+	field, ok := FocusedControl(w).(Validatable)
+	if !ok || field.Hidden() || field.ValidationError() == nil {
+		return
+	}
+	style := *theme.Error
+	message := field.ValidationError().Error()
+	margin := style.Margin.Int()
+	mw, mh := oneLineTextSize(style.Font.Face, message)
+	mw += 2 * margin
+	mh += 2 * margin
+
+	x, y := ControlAbsolute(field)
+	_, h := field.WidgetSize()
+	y += h
+	if y+mh > w.height {
+		y -= h + mh
+	}
+	x = max(0, min(x, w.width-mw))
+	FillFrameStyle(screen, x, y, mw, mh, style)
+	TextDrawOffsetStyle(screen, message, x, y, style)
+}
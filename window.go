@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"runtime/pprof"
@@ -38,6 +39,15 @@ type Window struct {
 	focusControl            Control
 	menuBar                 *MenuBar
 	dialogs                 *Stack
+	ticks                   int64               // ticks is the amount of ticks that Update was called.
+	recorder                *EventRecorder      // recorder records the input events if set.
+	input                   InputSource         // input is the source of the input events, if nil the ebiten input is used.
+	accelerators            []windowAccelerator // accelerators are the keyboard shortcuts of the window.
+	inspector               *Inspector          // inspector is the widget inspector, if it was shown.
+	hovered                 Hoverable           // hovered is the widget under the mouse pointer.
+	tabMoved                bool                // tabMoved is set if the last Tab key press moved the focus.
+	powerSaving             powerSaving         // powerSaving is the state of the power saving mode.
+	layoutQueue             []Control           // layoutQueue are the widgets that must be laid out again.
 	inputState
 	BasicOverlayer
 	Ability // Ability lets Window inherit abilities.
@@ -60,6 +70,8 @@ func NewWindow(title string, width, height int, hasMenubar bool) *Window {
 	w.SetStyle(&theme.Style)
 	w.dialogs = NewStack()
 	w.dialogs.SetParent(w)
+	w.enableInspectorFromEnv()
+	w.enablePowerSavingFromEnv()
 
 	return w
 }
@@ -93,6 +105,7 @@ func (w *Window) Toplevel() bool {
 const windowMargins = 4
 
 func (w *Window) LayoutWidget(parentWidth, parentHeight int) {
+	w.inspectLayout()
 	childY := 0
 	if w.dialogs != nil {
 		w.dialogs.LayoutWidget(parentWidth, parentHeight)
@@ -103,6 +116,9 @@ func (w *Window) LayoutWidget(parentWidth, parentHeight int) {
 		w.menuBar.MoveWidget(0, 0)
 		_, childY = w.menuBar.WidgetSize()
 	}
+	if w.inspector != nil && !w.inspector.Hidden() {
+		w.inspector.layout()
+	}
 	if w.child == nil {
 		return
 	}
@@ -120,8 +136,16 @@ func (w *Window) LayoutWidget(parentWidth, parentHeight int) {
 	childHeight := w.height - (2 * windowMargins)
 	dprintln("LayoutWidget ", w.width, w.height)
 	w.child.LayoutWidget(childWidth, childHeight)
-	w.child.MoveWidget(windowMargins, windowMargins+childY)
+	childX := windowMargins
+	if w.RightToLeft() {
+		cw, _ := w.child.WidgetSize()
+		childX = w.width - windowMargins - cw
+	}
+	w.child.MoveWidget(childX, windowMargins+childY)
 	w.needLayout = false
+	// Everything was laid out, so the queued widgets are done.
+	w.clearLayoutQueue(w.layoutQueue)
+	w.layoutQueue = nil
 }
 
 func (w *Window) SetChild(child Control) {
@@ -130,11 +154,30 @@ func (w *Window) SetChild(child Control) {
 	}
 	w.child = child
 	w.child.SetParent(w)
-	width, height := ebiten.WindowSize()
+	width, height := w.width, w.height
+	if !headless {
+		width, height = ebiten.WindowSize()
+	}
 	w.needLayout = true
 	w.LayoutWidget(width, height)
 }
 
+// Child returns the child widget of the window.
+func (w *Window) Child() Control {
+	return w.child
+}
+
+// focusRoot returns the widget of the window that receives the focused
+// events: the dialogs if one of them has the focus, otherwise the child.
+func (w *Window) focusRoot() Control {
+	if w.dialogs != nil {
+		if focus := w.dialogs.Focus(); focus != nil && !focus.Hidden() {
+			return w.dialogs
+		}
+	}
+	return w.child
+}
+
 func (w *Window) Destroy() {
 	// first hide ourselves
 	w.Hide()
@@ -195,11 +238,35 @@ func (w Window) OnFocusChanged(f func(*Window)) {
 }
 
 func Init() {
+	if goldenDir() != "" {
+		TestInit()
+		return
+	}
 	initResource()
 	initClipBoard()
+	if v, ok := os.LookupEnv("EBUI_REDUCED_MOTION"); ok && v == "1" {
+		SetReducedMotion(true)
+	}
 }
 
+// headless is set by TestInit. In headless mode the library does not touch
+// the ebiten window, the cursor or the input methods, so widgets can be used
+// without calling ebiten.RunGame.
+var headless = false
+
+// TestInit initializes the library for headless use, for example from go test.
+// The resources and the theme are loaded, but the clipboard, the input methods
+// and the ebiten window are not used. The animations are turned off, so the
+// results don't depend on the timing.
 func TestInit() {
+	headless = true
+	initResource()
+	SetReducedMotion(true)
+}
+
+// Headless returns whether the library was initialized with TestInit.
+func Headless() bool {
+	return headless
 }
 
 func Exit(code int) {
@@ -223,16 +290,113 @@ func (w *Window) Update() error {
 	if !w.Enabled() {
 		return nil
 	}
-	w.inputState.convertInputToEvents(w, func(e Event) {
+	w.ticks++
+	handle := func(e Event) {
+		if w.recorder != nil {
+			w.recorder.Record(w.ticks, e)
+		}
 		w.HandleWidget(e)
-	})
+	}
+
+	// Send an update event every time.
+	update := &UpdateEvent{BasicEvent: BasicEvent{EventOrigin: w}}
+	update.Duration = convertDuration(1) // one tick
+	handle(update)
+
+	events := w.Input().InputEvents(w)
+	for _, e := range events {
+		if e.Origin() == nil {
+			e.Event().EventOrigin = w
+		}
+		handle(e)
+		// The caches that pass the event on, and the widgets that the
+		// event changes, invalidate themselves.
+		RequestRedraw()
+	}
+	w.updatePowerSaving(len(events) > 0)
 
 	return nil
 }
 
+// Input returns the input source of the window.
+func (w *Window) Input() InputSource {
+	if w.input == nil {
+		return &w.inputState
+	}
+	return w.input
+}
+
+// SetInputSource sets the source of the input events of the window.
+// If input is nil, the default ebiten input is used again.
+func (w *Window) SetInputSource(input InputSource) {
+	w.input = input
+}
+
+// LiveInput returns the default input source of the window,
+// which polls the ebiten input.
+func (w *Window) LiveInput() InputSource {
+	return &w.inputState
+}
+
+// Ticks returns the amount of ticks the window was updated.
+func (w *Window) Ticks() int64 {
+	return w.ticks
+}
+
+// StartRecording starts recording the input events of the window to wr as
+// JSON lines, see EventRecord. Any previous recording is stopped.
+func (w *Window) StartRecording(wr io.Writer) *EventRecorder {
+	w.recorder = NewEventRecorder(wr, w.ticks)
+	return w.recorder
+}
+
+// StopRecording stops recording the input events.
+// It returns the first error that happened while recording.
+func (w *Window) StopRecording() error {
+	if w.recorder == nil {
+		return nil
+	}
+	err := w.recorder.Err()
+	w.recorder = nil
+	return err
+}
+
+// StartReplay replays the input events recorded in rd in stead of the
+// current input source, starting from the next tick. When the replay is done
+// the previous input source is used again.
+func (w *Window) StartReplay(rd io.Reader) *EventReplayer {
+	replayer := NewEventReplayer(rd)
+	w.input = &replaySource{replayer: replayer, start: w.ticks, previous: w.input}
+	return replayer
+}
+
+// StopReplay stops replaying and uses the previous input source again.
+func (w *Window) StopReplay() {
+	if replay, ok := w.input.(*replaySource); ok {
+		w.input = replay.previous
+	}
+}
+
+// redrawRequested is set if the window must be drawn again.
+var redrawRequested = true
+
+// RequestRedraw asks for the window to be drawn again. This is done
+// automatically for input events, layout, animations and the functions of
+// Do, After and Every. It only matters if the screen is not cleared every
+// frame, with ebiten.SetScreenClearedEveryFrame(false) or in power saving
+// mode, see Window.SetPowerSaving. Then the window is only drawn when a
+// redraw was requested, and the last drawing stays on the screen otherwise.
+func RequestRedraw() {
+	redrawRequested = true
+}
+
 // Draw draws the screen.
 // Draw is called every frame (typically 1/60[s] for 60Hz display).
 func (w *Window) Draw(screen *ebiten.Image) {
+	if !redrawRequested && !ebiten.IsScreenClearedEveryFrame() {
+		return
+	}
+	redrawRequested = false
 	w.DrawWidget(screen)
 	if w.drawDebug {
 		msg := fmt.Sprintf(`TPS: %0.2f
@@ -245,6 +409,10 @@ func (w *Window) Draw(screen *ebiten.Image) {
 func (w *Window) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHeight int) {
 	if w.width != outsideWidth || w.height != outsideHeight || w.needLayout {
 		w.LayoutWidget(outsideWidth, outsideHeight)
+		RequestRedraw()
+	} else if len(w.layoutQueue) > 0 {
+		w.layoutQueued()
+		RequestRedraw()
 	}
 	return w.width, w.height
 }
@@ -271,6 +439,34 @@ func (w *Window) HandleWidget(e Event) {
 		log.Printf("event: %#v\n", e)
 	}
 
+	// run the functions and timers of Do, After and Every, and the
+	// animations, before the widgets get the update.
+	if ue, ok := e.(*UpdateEvent); ok {
+		if ran := uiScheduler.run(ue.Duration); ran {
+			RequestRedraw()
+		}
+		runAnimations(ue.Duration)
+	}
+	if mm, ok := e.(*MouseMoveEvent); ok {
+		w.updateHover(mm.X, mm.Y)
+	}
+
+	// gamepad navigation, accelerators and tab navigation first.
+	if used := w.handleGamepad(e); used {
+		return
+	}
+	if used := w.handleAccelerator(e); used {
+		return
+	}
+	if w.inspector != nil {
+		if used := w.inspector.handle(e); used {
+			return
+		}
+	}
+	if used := w.handleTab(e); used {
+		return
+	}
+
 	// dialogs have highest priority
 	if w.dialogs != nil {
 		if used := HandleContainerIfNeeded(e, w.dialogs); used {
@@ -318,6 +514,45 @@ func (w *Window) DrawWidget(screen *Graphic) {
 	if w.dialogs != nil && w.dialogs.NumChildren() > 0 {
 		w.dialogs.DrawWidget(screen)
 	}
+
+	w.drawValidationMessage(screen)
+
+	// draw the inspector over everything.
+	if w.inspector != nil {
+		w.inspector.draw(screen)
+	}
+}
+
+// Hoverable is implemented by widgets that change when the mouse pointer
+// is over them.
+type Hoverable interface {
+	Control
+	// HoverWidget is called when the mouse pointer enters the widget with
+	// hovered true, and when it leaves with hovered false.
+	HoverWidget(hovered bool)
+}
+
+// updateHover tells the hoverable widgets that the pointer entered or left.
+func (w *Window) updateHover(x, y int) {
+	var hovered Hoverable
+	for c := ControlAt(w, x, y); c != nil; c = c.Parent() {
+		if h, ok := c.(Hoverable); ok && c.Enabled() {
+			hovered = h
+			break
+		}
+	}
+	if hovered == w.hovered {
+		return
+	}
+	if w.hovered != nil {
+		w.hovered.HoverWidget(false)
+		Invalidate(w.hovered)
+	}
+	w.hovered = hovered
+	if hovered != nil {
+		hovered.HoverWidget(true)
+		Invalidate(hovered)
+	}
 }
 
 func (w Window) StartDialog(dialog Control, title string, modal bool) {
@@ -329,6 +564,7 @@ func (w Window) StartDialog(dialog Control, title string, modal bool) {
 		pw, ph := pane.WidgetSize()
 		pane.MoveWidget(w.width/2-pw/2, w.height/2-ph/2)
 		w.dialogs.Append(pane)
+		pane.animateOpen()
 	} else {
 		// Otherwide create a pane for the dialog.
 		pad := w.Style().Margin.Int()
@@ -337,10 +573,16 @@ func (w Window) StartDialog(dialog Control, title string, modal bool) {
 		pane.SetChild(dialog)
 		pane.LayoutWidget(w.width, w.height)
 		w.dialogs.Append(pane)
+		pane.animateOpen()
 	}
 }
 
 func Main(w *Window) {
+	// render a golden image in stead if the env variable is set.
+	if dir := goldenDir(); dir != "" {
+		os.Exit(w.mainGolden(dir))
+	}
+
 	// enable profiling if the env variable is set.
 	if pf, ok := os.LookupEnv("EBUI_PPROF"); ok {
 		f, err := os.Create(pf)
@@ -352,6 +594,26 @@ func Main(w *Window) {
 		defer pprof.StopCPUProfile()
 	}
 
+	// record or replay the input if the env variables are set.
+	if name, ok := os.LookupEnv("EBUI_RECORD"); ok {
+		f, err := os.Create(name)
+		if err != nil {
+			fmt.Printf("error: %s", err.Error())
+			os.Exit(1)
+		}
+		defer f.Close()
+		w.StartRecording(f)
+	}
+	if name, ok := os.LookupEnv("EBUI_REPLAY"); ok {
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Printf("error: %s", err.Error())
+			os.Exit(1)
+		}
+		defer f.Close()
+		w.StartReplay(f)
+	}
+
 	ebiten.SetRunnableOnUnfocused(true)
 	w.Ability.setOnChanged(w.onAbilityChanged)
 